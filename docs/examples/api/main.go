@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"time"
 
 	gre "github.com/deadelus/go-rules-engine/v2/src"
 )
@@ -98,10 +97,9 @@ func main() {
 			almanac.AddFact(gre.FactID(k), v)
 		}
 
-		// Execute performance tracking
-		start := time.Now()
-		_, err := engine.Run(almanac)
-		duration := time.Since(start)
+		// Evaluate returns a per-request result, so the shared engine
+		// can serve concurrent requests safely
+		res, err := engine.Evaluate(almanac)
 
 		if err != nil {
 			sendJSON(w, http.StatusInternalServerError, Response{
@@ -113,10 +111,10 @@ func main() {
 
 		// Return results with audit trace
 		// We set the execution time in a header for visibility
-		w.Header().Set("X-Execution-Time", duration.String())
+		w.Header().Set("X-Execution-Time", res.Duration().String())
 		sendJSON(w, http.StatusOK, Response{
 			Success: true,
-			Results: engine.Results(),
+			Results: res.Results(),
 		})
 	})
 
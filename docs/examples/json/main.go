@@ -1,59 +1,22 @@
 package main
 
 import (
+	_ "embed"
 	"encoding/json"
 	"fmt"
 
 	gre "github.com/deadelus/go-rules-engine/v2/src"
 )
 
+// rulesJSON holds the rules definition, in the same format consumed by HTTPRuleProvider.
+//
+//go:embed rules.json
+var rulesJSON string
+
 func main() {
 	fmt.Println("🚀 JSON Example - Loading rules and data from JSON")
 	fmt.Println("================================================================")
 
-	// Rules JSON
-	rulesJSON := `[
-		{
-			"name": "vip-discount",
-			"priority": 100,
-			"conditions": {
-				"all": [
-					{
-						"condition": {
-							"fact": "customer.type",
-							"operator": "equal",
-							"value": "VIP"
-						}
-					},
-					{
-						"condition": {
-							"fact": "order.amount",
-							"operator": "greater_than",
-							"value": 200
-						}
-					}
-				]
-			},
-			"onSuccess": ["vip-discount-applied"]
-		},
-		{
-			"name": "regular-discount",
-			"priority": 50,
-			"conditions": {
-				"all": [
-					{
-						"condition": {
-							"fact": "order.amount",
-							"operator": "greater_than",
-							"value": 100
-						}
-					}
-				]
-			},
-			"onSuccess": ["regular-discount-applied"]
-		}
-	]`
-
 	// Facts JSON (data)
 	factsJSON := `{
 		"customer": {
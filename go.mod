@@ -2,4 +2,7 @@ module github.com/deadelus/go-rules-engine/v2
 
 go 1.24.5
 
-require github.com/oliveagle/jsonpath v0.0.0-20180606110733-2e52cf6e6852
+require (
+	github.com/oliveagle/jsonpath v0.0.0-20180606110733-2e52cf6e6852
+	gopkg.in/yaml.v3 v3.0.1
+)
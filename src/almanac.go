@@ -1,6 +1,8 @@
 package gorulesengine
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -30,6 +32,8 @@ type Almanac struct {
 	facts                 map[FactID]*Fact
 	factResultsCache      map[string]interface{}
 	conditionResultsCache map[string]interface{}
+	ruleResults           map[string]bool
+	tracker               *factTracker
 	pathResolver          PathResolver
 	options               map[string]interface{}
 	mutex                 sync.RWMutex
@@ -41,13 +45,39 @@ type AlmanacOption func(*Almanac)
 // PathResolver resolves nested values within facts using a path expression (e.g., JSONPath).
 type PathResolver func(value interface{}, path string) (interface{}, error)
 
+// ErrPathNotFound is wrapped by path resolution errors when a path does not lead
+// to a value of the fact (missing key, index out of range, null object...), as
+// opposed to an invalid path.
+var ErrPathNotFound = errors.New("path not found")
+
+// FactStatus tells whether a fact value could be resolved by the Almanac.
+type FactStatus string
+
+const (
+	// FactFound indicates that the fact is defined and its path resolved. The value may be nil.
+	FactFound FactStatus = "found"
+	// FactUndefined indicates that the fact is not defined in the almanac.
+	FactUndefined FactStatus = "undefined"
+	// FactPathNotFound indicates that the fact is defined but its path does not resolve.
+	FactPathNotFound FactStatus = "path_not_found"
+)
+
 // DefaultPathResolver implements JSONPath resolution for accessing nested fact values.
 // Example: "$.user.profile.age" accesses deeply nested data.
+// Paths that do not resolve against the value return an error wrapping ErrPathNotFound.
 func DefaultPathResolver(value interface{}, path string) (interface{}, error) {
 	if path == "" {
 		return value, nil
 	}
-	return jsonpath.JsonPathLookup(value, path)
+	compiled, err := jsonpath.Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	val, err := compiled.Lookup(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPathNotFound, err)
+	}
+	return val, nil
 }
 
 // AllowUndefinedFacts configures the almanac to return nil instead of errors for undefined facts.
@@ -78,6 +108,7 @@ func NewAlmanac(opts ...AlmanacOption) *Almanac {
 		facts:                 make(map[FactID]*Fact),
 		factResultsCache:      make(map[string]interface{}),
 		conditionResultsCache: make(map[string]interface{}),
+		ruleResults:           make(map[string]bool),
 		pathResolver:          DefaultPathResolver,
 		options:               make(map[string]interface{}),
 	}
@@ -96,6 +127,7 @@ func NewAlmanac(opts ...AlmanacOption) *Almanac {
 // AddFact adds a fact to the almanac.
 // The valueOrMethod can be either a static value or a function for dynamic facts.
 // Optional FactOptions can be provided to configure caching and priority.
+// A dynamic fact with an unsupported signature is rejected with a FactError.
 //
 // Example:
 //
@@ -111,9 +143,10 @@ func (a *Almanac) AddFact(id FactID, valueOrMethod interface{}, opts ...FactOpti
 	defer a.mutex.Unlock()
 
 	fact := NewFact(id, valueOrMethod, opts...)
-	a.facts[id] = &fact
-
-	a.PreCacheFactValue(&fact)
+	if err := fact.Validate(); err != nil {
+		return err
+	}
+	a.setFact(&fact)
 
 	return nil
 }
@@ -124,8 +157,34 @@ func (a *Almanac) AddFacts(facts ...*Fact) {
 	defer a.mutex.Unlock()
 
 	for _, fact := range facts {
-		a.facts[fact.ID()] = fact
-		a.PreCacheFactValue(fact)
+		a.setFact(fact)
+	}
+}
+
+// setFact stores a fact, replacing any fact with the same ID.
+// Replacing a fact drops its cached value and the cached condition results,
+// and is recorded as an assertion while forward chaining tracks changes.
+// The caller must hold the write lock.
+func (a *Almanac) setFact(fact *Fact) {
+	old, exists := a.facts[fact.ID()]
+	changed := !exists || old.IsDynamic() || fact.IsDynamic() ||
+		!reflect.DeepEqual(old.ValueOrMethod(), fact.ValueOrMethod())
+
+	if exists && changed {
+		if key, _ := old.GetCacheKey(); key != "" {
+			delete(a.factResultsCache, key)
+		}
+	}
+	// Cached conditions may have read the fact as undefined (exists, not_exists...)
+	if changed && len(a.conditionResultsCache) > 0 {
+		a.conditionResultsCache = make(map[string]interface{})
+	}
+
+	a.facts[fact.ID()] = fact
+	a.PreCacheFactValue(fact)
+
+	if changed && a.tracker != nil {
+		a.tracker.record(fact)
 	}
 }
 
@@ -152,18 +211,20 @@ func (a *Almanac) PreCacheFactValue(fact *Fact) {
 //	// Nested access with JSONPath
 //	city, _ := almanac.GetFactValue("user", nil, "$.address.city")
 func (a *Almanac) GetFactValue(factID FactID, params map[string]interface{}, path string) (interface{}, error) {
-	var fact *Fact
-	var exists bool
-	var cachedVal interface{}
-	var cached bool
+	return a.GetFactValueContext(context.Background(), factID, params, path)
+}
 
-	// Read lock for concurrent access
-	a.mutex.RLock()
-	fact, exists = a.facts[factID]
-	a.mutex.RUnlock()
+// GetFactValueContext retrieves the value of a fact by its ID, like GetFactValue.
+// The context is passed to context-aware dynamic facts; if it is already done
+// before the fact is computed, its error is returned.
+func (a *Almanac) GetFactValueContext(ctx context.Context, factID FactID, params map[string]interface{}, path string) (interface{}, error) {
+	val, status, err := a.lookupFactValue(ctx, factID, params, path)
+	if err != nil {
+		return nil, err
+	}
 
 	// Fact not found
-	if !exists {
+	if status == FactUndefined {
 		// Check if undefined facts are allowed
 		if allowUndefined, ok := a.options[AlmanacOptionKeyAllowUndefinedFacts].(bool); ok && allowUndefined {
 			return nil, nil
@@ -174,6 +235,46 @@ func (a *Almanac) GetFactValue(factID FactID, params map[string]interface{}, pat
 		}
 	}
 
+	return val, nil
+}
+
+// LookupFactValue retrieves the value of a fact like GetFactValueContext, and
+// reports whether it could be resolved, whatever the AllowUndefinedFacts option:
+// an undefined fact returns FactUndefined and a path that does not resolve
+// (including a path into a nil fact) FactPathNotFound, both without error.
+// A found fact may still be nil.
+//
+// Example:
+//
+//	zip, status, err := almanac.LookupFactValue(ctx, "user", nil, "$.address.zip")
+//	if err == nil && status == gre.FactPathNotFound {
+//	    // the user has no zip code
+//	}
+func (a *Almanac) LookupFactValue(ctx context.Context, factID FactID, params map[string]interface{}, path string) (interface{}, FactStatus, error) {
+	val, status, err := a.lookupFactValue(ctx, factID, params, path)
+	if status == FactPathNotFound {
+		return nil, status, nil
+	}
+	return val, status, err
+}
+
+// lookupFactValue retrieves the value of a fact and its status. A path that does
+// not resolve returns FactPathNotFound along with its resolution error, if any.
+func (a *Almanac) lookupFactValue(ctx context.Context, factID FactID, params map[string]interface{}, path string) (interface{}, FactStatus, error) {
+	var fact *Fact
+	var exists bool
+	var cachedVal interface{}
+	var cached bool
+
+	// Read lock for concurrent access
+	a.mutex.RLock()
+	fact, exists = a.facts[factID]
+	a.mutex.RUnlock()
+
+	if !exists {
+		return nil, FactUndefined, nil
+	}
+
 	// Check cache first
 	if val, _ := fact.GetOption(FactOptionKeyCache); val == true {
 		cachedVal, cached = a.GetFactValueFromCache(factID)
@@ -185,8 +286,24 @@ func (a *Almanac) GetFactValue(factID FactID, params map[string]interface{}, pat
 	if cached {
 		val = cachedVal
 	} else {
+		if err := ctx.Err(); err != nil {
+			return nil, FactFound, err
+		}
+
+		// Facts with an unsupported signature carry their own FactError
+		if err := fact.Validate(); err != nil {
+			return nil, FactFound, err
+		}
+
 		// Calculate fact value
-		val = fact.Calculate(params)
+		var err error
+		val, err = fact.CalculateContext(ctx, params)
+		if err != nil {
+			return nil, FactFound, &FactError{
+				Fact: *fact,
+				Err:  err,
+			}
+		}
 
 		// Cache the result if caching is enabled
 		if cacheEnabled, ok := fact.options[FactOptionKeyCache].(bool); ok && cacheEnabled {
@@ -198,16 +315,25 @@ func (a *Almanac) GetFactValue(factID FactID, params map[string]interface{}, pat
 		}
 	}
 
+	// A nil fact has no nested values
+	if path != "" && val == nil {
+		return nil, FactPathNotFound, nil
+	}
+
 	// Apply path resolution if path is provided
 	val, err := a.TraversePath(val, path)
 	if err != nil {
-		return nil, &AlmanacError{
+		status := FactFound
+		if errors.Is(err, ErrPathNotFound) {
+			status = FactPathNotFound
+		}
+		return nil, status, &AlmanacError{
 			Payload: fmt.Sprintf("factID=%s, path=%s", factID, path),
-			Err:     fmt.Errorf("failed to resolve path '%s' for fact '%s': %v", path, factID, err),
+			Err:     fmt.Errorf("failed to resolve path '%s' for fact '%s': %w", path, factID, err),
 		}
 	}
 
-	return val, nil
+	return val, FactFound, nil
 }
 
 // GetFactValueFromCache retrieves a fact value directly from the cache
@@ -241,6 +367,85 @@ func (a *Almanac) SetConditionResultCache(key string, result interface{}) {
 	a.conditionResultsCache[key] = result
 }
 
+// GetRuleResult returns the outcome of a rule evaluated against this almanac,
+// as read by rule conditions ({"rule": "name", ...}).
+func (a *Almanac) GetRuleResult(name string) (bool, bool) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	result, ok := a.ruleResults[name]
+	return result, ok
+}
+
+// SetRuleResult stores the outcome of a rule. The engine calls it for every
+// evaluated (or skipped) rule, before evaluating the rules that depend on it.
+func (a *Almanac) SetRuleResult(name string, result bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.ruleResults == nil {
+		a.ruleResults = make(map[string]bool)
+	}
+	// Cached rule conditions must see the new outcome
+	if previous, ok := a.ruleResults[name]; ok && previous != result {
+		a.conditionResultsCache = make(map[string]interface{})
+	}
+	a.ruleResults[name] = result
+}
+
+// fork returns a copy of the almanac holding the same facts, options and cached
+// fact values, with no cached condition or rule results. Asserting facts in the
+// copy does not affect the almanac.
+func (a *Almanac) fork() *Almanac {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	f := &Almanac{
+		facts:                 make(map[FactID]*Fact, len(a.facts)),
+		factResultsCache:      make(map[string]interface{}, len(a.factResultsCache)),
+		conditionResultsCache: make(map[string]interface{}),
+		ruleResults:           make(map[string]bool),
+		pathResolver:          a.pathResolver,
+		options:               make(map[string]interface{}, len(a.options)),
+	}
+	for id, fact := range a.facts {
+		f.facts[id] = fact
+	}
+	for key, value := range a.factResultsCache {
+		f.factResultsCache[key] = value
+	}
+	for key, value := range a.options {
+		f.options[key] = value
+	}
+	return f
+}
+
+// seedComputedFacts copies the cached values of the dynamic facts computed by
+// other into the almanac, so they are not computed again. Facts replaced in
+// either almanac since they were forked (e.g. asserted by setFact) are left out.
+func (a *Almanac) seedComputedFacts(other *Almanac) {
+	other.mutex.RLock()
+	defer other.mutex.RUnlock()
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for id, fact := range a.facts {
+		if !fact.IsDynamic() || other.facts[id] != fact {
+			continue
+		}
+		key, _ := fact.GetCacheKey()
+		if key == "" {
+			continue
+		}
+		if _, cached := a.factResultsCache[key]; cached {
+			continue
+		}
+		if value, ok := other.factResultsCache[key]; ok {
+			a.factResultsCache[key] = value
+		}
+	}
+}
+
 // IsConditionCachingEnabled checks if condition caching is enabled in the almanac.
 func (a *Almanac) IsConditionCachingEnabled() bool {
 	enabled, ok := a.options[AlmanacOptionKeyCacheConditions].(bool)
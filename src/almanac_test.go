@@ -1,6 +1,7 @@
 package gorulesengine_test
 
 import (
+	"errors"
 	"testing"
 
 	gre "github.com/deadelus/go-rules-engine/v2/src"
@@ -157,3 +158,31 @@ func TestGetFactValue_UndefinedFactAllowed(t *testing.T) {
 		t.Errorf("Expected nil value for undefined fact, got %v", val)
 	}
 }
+
+func TestAddFact_UnsupportedSignature(t *testing.T) {
+	almanac := gre.NewAlmanac()
+
+	err := almanac.AddFact("broken", func(a, b int) int { return a + b })
+
+	var factErr *gre.FactError
+	if !errors.As(err, &factErr) {
+		t.Fatalf("Expected FactError, got %v", err)
+	}
+	if _, exists := almanac.GetFacts()["broken"]; exists {
+		t.Error("Expected invalid fact not to be added")
+	}
+}
+
+func TestGetFactValue_FactErrorNotMaskedByUndefinedFacts(t *testing.T) {
+	almanac := gre.NewAlmanac(gre.AllowUndefinedFacts())
+	almanac.AddFact("balance", func(params map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("db unavailable")
+	})
+
+	_, err := almanac.GetFactValue("balance", nil, "")
+
+	var factErr *gre.FactError
+	if !errors.As(err, &factErr) {
+		t.Fatalf("Expected FactError, got %v", err)
+	}
+}
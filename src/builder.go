@@ -1,5 +1,10 @@
 package gorulesengine
 
+import (
+	"strings"
+	"time"
+)
+
 // RuleBuilder provides a fluent API for building rules.
 type RuleBuilder struct {
 	rule *Rule
@@ -24,11 +29,23 @@ func (rb *RuleBuilder) WithPriority(priority int) *RuleBuilder {
 	return rb
 }
 
+// WithEffect sets the decision the rule votes for under override hit policies.
+func (rb *RuleBuilder) WithEffect(effect RuleEffect) *RuleBuilder {
+	rb.rule.Effect = effect
+	return rb
+}
+
+// WithStopProcessing halts the run after the rule, depending on its outcome.
+func (rb *RuleBuilder) WithStopProcessing(mode StopMode) *RuleBuilder {
+	rb.rule.StopProcessing = mode
+	return rb
+}
+
 // WithConditions sets the conditions for the rule.
 func (rb *RuleBuilder) WithConditions(node ConditionNode) *RuleBuilder {
-	if node.Condition != nil || node.SubSet != nil {
+	if node.Condition != nil || node.SubSet != nil || node.Quantifier != nil {
 		rb.rule.Conditions = ConditionSet{}
-		if node.Condition != nil {
+		if node.Condition != nil || node.Quantifier != nil {
 			rb.rule.Conditions.All = []ConditionNode{node}
 		} else if node.SubSet != nil {
 			rb.rule.Conditions = ConditionSet{
@@ -179,6 +196,301 @@ func Regex(fact string, pattern string) *Condition {
 	}
 }
 
+// Between creates a condition that checks if min <= fact <= max.
+func Between(fact string, min, max interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorBetween,
+		Value:    []interface{}{min, max},
+	}
+}
+
+// BetweenExclusive creates a condition that checks if min < fact < max.
+func BetweenExclusive(fact string, min, max interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorBetween,
+		Value:    map[string]interface{}{"min": min, "max": max, "bounds": "()"},
+	}
+}
+
+// NotBetween creates a condition that checks if fact < min or fact > max.
+func NotBetween(fact string, min, max interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorNotBetween,
+		Value:    []interface{}{min, max},
+	}
+}
+
+// StartsWith creates a condition that checks if fact starts with prefix.
+func StartsWith(fact string, prefix string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorStartsWith,
+		Value:    prefix,
+	}
+}
+
+// EndsWith creates a condition that checks if fact ends with suffix.
+func EndsWith(fact string, suffix string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorEndsWith,
+		Value:    suffix,
+	}
+}
+
+// EqualIgnoreCase creates a condition that checks if fact equals value, ignoring case.
+func EqualIgnoreCase(fact string, value string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorEqualIgnoreCase,
+		Value:    value,
+	}
+}
+
+// InIgnoreCase creates a condition that checks if fact is in a list of strings, ignoring case.
+func InIgnoreCase(fact string, values ...string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorInIgnoreCase,
+		Value:    values,
+	}
+}
+
+// LengthEqual creates a condition that checks if len(fact) == n.
+func LengthEqual(fact string, n int) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorLengthEqual,
+		Value:    n,
+	}
+}
+
+// LengthGreaterThan creates a condition that checks if len(fact) > n.
+func LengthGreaterThan(fact string, n int) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorLengthGreaterThan,
+		Value:    n,
+	}
+}
+
+// LengthGreaterThanInclusive creates a condition that checks if len(fact) >= n.
+func LengthGreaterThanInclusive(fact string, n int) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorLengthGreaterThanInclusive,
+		Value:    n,
+	}
+}
+
+// LengthLessThan creates a condition that checks if len(fact) < n.
+func LengthLessThan(fact string, n int) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorLengthLessThan,
+		Value:    n,
+	}
+}
+
+// LengthLessThanInclusive creates a condition that checks if len(fact) <= n.
+func LengthLessThanInclusive(fact string, n int) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorLengthLessThanInclusive,
+		Value:    n,
+	}
+}
+
+// Exists creates a condition that checks if fact is defined and path (optional) resolves.
+func Exists(fact string, path string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorExists,
+		Path:     path,
+	}
+}
+
+// NotExists creates a condition that checks if fact is undefined or path (optional) does not resolve.
+func NotExists(fact string, path string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorNotExists,
+		Path:     path,
+	}
+}
+
+// IsNull creates a condition that checks if fact (at path, optional) exists with a nil value.
+func IsNull(fact string, path string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorIsNull,
+		Path:     path,
+	}
+}
+
+// IsEmpty creates a condition that checks if fact (at path, optional) is missing,
+// nil, or an empty string, array or map.
+func IsEmpty(fact string, path string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorIsEmpty,
+		Path:     path,
+	}
+}
+
+// IsNotEmpty creates a condition that checks if fact (at path, optional) exists
+// and is neither nil nor empty.
+func IsNotEmpty(fact string, path string) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorIsNotEmpty,
+		Path:     path,
+	}
+}
+
+// Before creates a condition that checks if the fact time is before t
+// (a time.Time, an RFC3339 string or epoch seconds).
+func Before(fact string, t interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorBefore,
+		Value:    t,
+	}
+}
+
+// After creates a condition that checks if the fact time is after t
+// (a time.Time, an RFC3339 string or epoch seconds).
+func After(fact string, t interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorAfter,
+		Value:    t,
+	}
+}
+
+// BetweenDates creates a condition that checks if the fact time is within [from, to].
+func BetweenDates(fact string, from, to interface{}) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorBetweenDates,
+		Value:    []interface{}{from, to},
+	}
+}
+
+// WithinLast creates a condition that checks if the fact time is within d before now.
+func WithinLast(fact string, d time.Duration) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorWithinLast,
+		Value:    d.String(),
+	}
+}
+
+// OlderThan creates a condition that checks if the fact time is more than d before now.
+func OlderThan(fact string, d time.Duration) *Condition {
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorOlderThan,
+		Value:    d.String(),
+	}
+}
+
+// DayOfWeekIn creates a condition that checks if the fact time falls on one of
+// days in the timezone (an IANA name, or "" for the location of the time).
+func DayOfWeekIn(fact string, timezone string, days ...time.Weekday) *Condition {
+	names := make([]interface{}, len(days))
+	for i, day := range days {
+		names[i] = strings.ToLower(day.String())
+	}
+	value := map[string]interface{}{"days": names}
+	if timezone != "" {
+		value["timezone"] = timezone
+	}
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorDayOfWeekIn,
+		Value:    value,
+	}
+}
+
+// TimeOfDayBetween creates a condition that checks if the fact time of day is within
+// [from, to) (HH:MM or HH:MM:SS, spanning midnight if from is after to) in the
+// timezone (an IANA name, or "" for the location of the time).
+func TimeOfDayBetween(fact string, from, to string, timezone string) *Condition {
+	value := map[string]interface{}{"from": from, "to": to}
+	if timezone != "" {
+		value["timezone"] = timezone
+	}
+	return &Condition{
+		Fact:     FactID(fact),
+		Operator: OperatorTimeOfDayBetween,
+		Value:    value,
+	}
+}
+
+// FactRef creates a condition value referring to another fact (and optional JSONPath),
+// for fact-to-fact comparisons.
+//
+// Example:
+//
+//	cond := gre.GreaterThan("amount", gre.FactRef("account", "$.dailyLimit"))
+func FactRef(fact string, path string) *FactReference {
+	return &FactReference{
+		Fact: FactID(fact),
+		Path: path,
+	}
+}
+
+// Some creates a quantifier that checks if at least one element of the array fact
+// (at path, optional) matches where. Elements are read as the "$item" fact.
+func Some(fact string, path string, where ConditionSet) *QuantifierCondition {
+	return &QuantifierCondition{
+		Fact:       FactID(fact),
+		Path:       path,
+		Quantifier: QuantifierSome,
+		Where:      where,
+	}
+}
+
+// Every creates a quantifier that checks if all elements of the array fact
+// (at path, optional) match where. Elements are read as the "$item" fact.
+func Every(fact string, path string, where ConditionSet) *QuantifierCondition {
+	return &QuantifierCondition{
+		Fact:       FactID(fact),
+		Path:       path,
+		Quantifier: QuantifierEvery,
+		Where:      where,
+	}
+}
+
+// NoneOf creates a quantifier that checks if no element of the array fact
+// (at path, optional) matches where. Elements are read as the "$item" fact.
+func NoneOf(fact string, path string, where ConditionSet) *QuantifierCondition {
+	return &QuantifierCondition{
+		Fact:       FactID(fact),
+		Path:       path,
+		Quantifier: QuantifierNoneOf,
+		Where:      where,
+	}
+}
+
+// Count creates a quantifier that compares the number of elements of the array
+// fact (at path, optional) matching where with value, using operator.
+// Elements are read as the "$item" fact.
+func Count(fact string, path string, where ConditionSet, operator OperatorType, value interface{}) *QuantifierCondition {
+	return &QuantifierCondition{
+		Fact:       FactID(fact),
+		Path:       path,
+		Quantifier: QuantifierCount,
+		Where:      where,
+		Operator:   operator,
+		Value:      value,
+	}
+}
+
 // ConditionSet Helper Functions
 
 // All creates a ConditionSet where all conditions must be true.
@@ -1,6 +1,7 @@
 package gorulesengine
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
@@ -9,6 +10,8 @@ import (
 
 // Condition represents a single condition that compares a fact value against an expected value using an operator.
 // Conditions can optionally use JSONPath to access nested values within facts.
+// A condition with a Rule instead of a Fact compares the result (true/false) of
+// another rule of the engine, which is then evaluated first.
 //
 // Example:
 //
@@ -17,15 +20,76 @@ import (
 //	    Operator: "greater_than",
 //	    Value:    18,
 //	}
+//
+//	chained := &gre.Condition{
+//	    Rule:     "high-risk-transaction",
+//	    Operator: "equal",
+//	    Value:    true,
+//	}
 type Condition struct {
-	Fact      FactID                 `json:"fact"`             // The fact identifier to evaluate
-	Operator  OperatorType           `json:"operator"`         // The comparison operator to use
-	Value     interface{}            `json:"value"`            // The expected value to compare against
-	Path      string                 `json:"path,omitempty"`   // Optional JSONPath to access nested fact values
-	Params    map[string]interface{} `json:"params,omitempty"` // Optional parameters for dynamic facts
+	Fact      FactID                 `json:"fact,omitempty" yaml:"fact,omitempty"`     // The fact identifier to evaluate
+	Rule      string                 `json:"rule,omitempty" yaml:"rule,omitempty"`     // The rule whose result is evaluated instead of a fact
+	Operator  OperatorType           `json:"operator" yaml:"operator"`                 // The comparison operator to use
+	Value     interface{}            `json:"value" yaml:"value"`                       // The expected value to compare against
+	Path      string                 `json:"path,omitempty" yaml:"path,omitempty"`     // Optional JSONPath to access nested fact values
+	Params    map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"` // Optional parameters for dynamic facts
 	cachedKey string                 // Pre-calculated cache key
 }
 
+// FactReference is a condition value that refers to another fact, for fact-to-fact
+// comparisons. It is resolved through the Almanac at evaluation time.
+//
+// In JSON/YAML, a value that is an object with a non-empty "fact" string (and
+// optionally "path" and "params", nothing else) is read as a FactReference.
+//
+// Example:
+//
+//	condition := &gre.Condition{
+//	    Fact:     "transaction",
+//	    Path:     "$.amount",
+//	    Operator: "greater_than",
+//	    Value:    &gre.FactReference{Fact: "account", Path: "$.dailyLimit"},
+//	}
+type FactReference struct {
+	Fact   FactID                 `json:"fact" yaml:"fact"`                         // The referenced fact
+	Path   string                 `json:"path,omitempty" yaml:"path,omitempty"`     // Optional JSONPath into the referenced fact
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"` // Optional parameters for a dynamic referenced fact
+}
+
+// asFactReference returns the fact reference held by a condition value, if any.
+// It accepts a FactReference (or pointer) and the decoded JSON/YAML object form.
+func asFactReference(value interface{}) (*FactReference, bool) {
+	switch v := value.(type) {
+	case *FactReference:
+		return v, v != nil
+	case FactReference:
+		return &v, true
+	case map[string]interface{}:
+		fact, ok := v["fact"].(string)
+		if !ok || fact == "" {
+			return nil, false
+		}
+		ref := &FactReference{Fact: FactID(fact)}
+		for key, val := range v {
+			switch key {
+			case "fact":
+			case "path":
+				if ref.Path, ok = val.(string); !ok {
+					return nil, false
+				}
+			case "params":
+				if ref.Params, ok = val.(map[string]interface{}); !ok && val != nil {
+					return nil, false
+				}
+			default:
+				return nil, false
+			}
+		}
+		return ref, true
+	}
+	return nil, false
+}
+
 // ConditionSet represents a group of conditions combined with logical operators (all/any/none).
 // ConditionSets can be nested to create complex boolean logic.
 //
@@ -38,25 +102,44 @@ type Condition struct {
 //	    },
 //	}
 type ConditionSet struct {
-	All       []ConditionNode `json:"all,omitempty"`  // All conditions must be true (AND)
-	Any       []ConditionNode `json:"any,omitempty"`  // At least one condition must be true (OR)
-	None      []ConditionNode `json:"none,omitempty"` // No conditions must be true (NOT)
+	All       []ConditionNode `json:"all,omitempty" yaml:"all,omitempty"`   // All conditions must be true (AND)
+	Any       []ConditionNode `json:"any,omitempty" yaml:"any,omitempty"`   // At least one condition must be true (OR)
+	None      []ConditionNode `json:"none,omitempty" yaml:"none,omitempty"` // No conditions must be true (NOT)
 	cachedKey string          // Pre-calculated cache key
 }
 
-// ConditionNode represents either a single Condition or a nested ConditionSet.
+// ConditionNode represents either a single Condition, a nested ConditionSet or a
+// QuantifierCondition over the elements of an array fact.
 // This allows for recursive nesting of conditions to build complex boolean expressions.
 type ConditionNode struct {
-	Condition *Condition    // A single condition to evaluate
-	SubSet    *ConditionSet // A nested set of conditions
+	Condition  *Condition           // A single condition to evaluate
+	SubSet     *ConditionSet        // A nested set of conditions
+	Quantifier *QuantifierCondition // Nested conditions applied to each element of a collection
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for ConditionNode.
-// It attempts to unmarshal either a Condition or a ConditionSet from the JSON data.
+// An object with a "quantifier" is read as a QuantifierCondition; otherwise it
+// attempts to unmarshal either a Condition or a ConditionSet from the JSON data.
 func (n *ConditionNode) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Quantifier *Quantifier `json:"quantifier"`
+	}
+	if err := json.Unmarshal(data, &probe); err == nil && probe.Quantifier != nil {
+		var quantifier QuantifierCondition
+		if err := json.Unmarshal(data, &quantifier); err != nil {
+			return &RuleEngineError{
+				Type: ErrJSON,
+				Msg:  "failed to unmarshal ConditionNode",
+				Err:  fmt.Errorf("data: %s, error: %w", string(data), err),
+			}
+		}
+		n.Quantifier = &quantifier
+		return nil
+	}
+
 	var cond Condition
 	err1 := json.Unmarshal(data, &cond)
-	if err1 == nil && cond.Fact != "" {
+	if err1 == nil && (cond.Fact != "" || cond.Rule != "") {
 		n.Condition = &cond
 		return nil
 	}
@@ -76,26 +159,56 @@ func (n *ConditionNode) UnmarshalJSON(data []byte) error {
 	}
 }
 
+// MarshalJSON implements custom JSON marshaling for ConditionNode.
+// A node is written as its Condition, SubSet or Quantifier directly, so the output
+// can be read back by UnmarshalJSON and by the rule loaders.
+func (n ConditionNode) MarshalJSON() ([]byte, error) {
+	if n.Condition != nil {
+		return json.Marshal(n.Condition)
+	}
+	if n.SubSet != nil {
+		return json.Marshal(n.SubSet)
+	}
+	if n.Quantifier != nil {
+		return json.Marshal(n.Quantifier)
+	}
+
+	return nil, &RuleEngineError{
+		Type: ErrJSON,
+		Msg:  "failed to marshal ConditionNode",
+		Err:  fmt.Errorf("invalid condition node: neither condition nor subset is defined"),
+	}
+}
+
 // Evaluate evaluates the condition node, whether it's a condition or a subset
-func evaluateConditionNode(node *ConditionNode, almanac *Almanac) (*ConditionNodeResult, error) {
+func evaluateConditionNode(ctx context.Context, node *ConditionNode, almanac *Almanac) (*ConditionNodeResult, error) {
 	if node.Condition != nil {
-		res, err := node.Condition.Evaluate(almanac)
+		res, err := node.Condition.EvaluateContext(ctx, almanac)
 		if err != nil {
-			return nil, &ConditionError{
+			return &ConditionNodeResult{Condition: res}, &ConditionError{
 				Condition: *node.Condition,
-				Err:       fmt.Errorf("failed to evaluate condition node: %v", err),
+				Err:       fmt.Errorf("failed to evaluate condition node: %w", err),
 			}
 		}
 		return &ConditionNodeResult{Condition: res}, nil
 	} else if node.SubSet != nil {
-		res, err := node.SubSet.Evaluate(almanac)
+		res, err := node.SubSet.EvaluateContext(ctx, almanac)
 		if err != nil {
-			return nil, &ConditionError{
+			return &ConditionNodeResult{ConditionSet: res}, &ConditionError{
 				Condition: Condition{},
-				Err:       fmt.Errorf("failed to evaluate condition subset node: %v", err),
+				Err:       fmt.Errorf("failed to evaluate condition subset node: %w", err),
 			}
 		}
 		return &ConditionNodeResult{ConditionSet: res}, nil
+	} else if node.Quantifier != nil {
+		res, err := node.Quantifier.EvaluateContext(ctx, almanac)
+		if err != nil {
+			return &ConditionNodeResult{Quantifier: res}, &ConditionError{
+				Condition: Condition{Fact: node.Quantifier.Fact, Path: node.Quantifier.Path},
+				Err:       fmt.Errorf("failed to evaluate quantifier node: %w", err),
+			}
+		}
+		return &ConditionNodeResult{Quantifier: res}, nil
 	}
 
 	return nil, &ConditionError{
@@ -113,7 +226,7 @@ func (c *Condition) GetCacheKey() (string, error) {
 	if err != nil {
 		return "", &ConditionError{
 			Condition: *c,
-			Err:       fmt.Errorf("failed to marshal condition for cache key: %v", err),
+			Err:       fmt.Errorf("failed to marshal condition for cache key: %w", err),
 		}
 	}
 	sum := md5.Sum(bytes)
@@ -126,16 +239,25 @@ func (c *Condition) Compile() error {
 	if err != nil {
 		return &ConditionError{
 			Condition: *c,
-			Err:       fmt.Errorf("failed to compile condition: %v", err),
+			Err:       fmt.Errorf("failed to compile condition: %w", err),
 		}
 	}
 	c.cachedKey = key
 	return nil
 }
 
-// GetRequiredFacts returns the list of facts required by this condition.
+// GetRequiredFacts returns the list of facts required by this condition,
+// including the fact referenced by its value, if any.
+// A rule condition requires no fact of its own.
 func (c *Condition) GetRequiredFacts() []FactID {
-	return []FactID{c.Fact}
+	facts := make([]FactID, 0, 2)
+	if c.Rule == "" {
+		facts = append(facts, c.Fact)
+	}
+	if ref, ok := asFactReference(c.Value); ok {
+		facts = append(facts, ref.Fact)
+	}
+	return facts
 }
 
 // GetRequiredFacts returns the list of facts required by this condition node.
@@ -144,6 +266,8 @@ func (n *ConditionNode) GetRequiredFacts() []FactID {
 		return n.Condition.GetRequiredFacts()
 	} else if n.SubSet != nil {
 		return n.SubSet.GetRequiredFacts()
+	} else if n.Quantifier != nil {
+		return n.Quantifier.GetRequiredFacts()
 	}
 	return []FactID{}
 }
@@ -180,16 +304,20 @@ func (cs *ConditionSet) Compile() error {
 				if err := nodes[i].Condition.Compile(); err != nil {
 					return &ConditionError{
 						Condition: *nodes[i].Condition,
-						Err:       fmt.Errorf("failed to compile condition in condition set: %v", err),
+						Err:       fmt.Errorf("failed to compile condition in condition set: %w", err),
 					}
 				}
 			} else if nodes[i].SubSet != nil {
 				if err := nodes[i].SubSet.Compile(); err != nil {
 					return &ConditionError{
 						Condition: Condition{},
-						Err:       fmt.Errorf("failed to compile subset in condition set: %v", err),
+						Err:       fmt.Errorf("failed to compile subset in condition set: %w", err),
 					}
 				}
+			} else if nodes[i].Quantifier != nil {
+				if err := nodes[i].Quantifier.Compile(); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -209,7 +337,7 @@ func (cs *ConditionSet) Compile() error {
 	if err != nil {
 		return &ConditionError{
 			Condition: Condition{},
-			Err:       fmt.Errorf("failed to compile condition set: %v", err),
+			Err:       fmt.Errorf("failed to compile condition set: %w", err),
 		}
 	}
 	cs.cachedKey = key
@@ -219,23 +347,31 @@ func (cs *ConditionSet) Compile() error {
 
 // Evaluate evaluates the condition against the almanac
 func (c *Condition) Evaluate(almanac *Almanac) (*ConditionResult, error) {
+	return c.EvaluateContext(context.Background(), almanac)
+}
+
+// EvaluateContext evaluates the condition against the almanac.
+// The context is propagated to dynamic facts and aborts the evaluation once done.
+func (c *Condition) EvaluateContext(ctx context.Context, almanac *Almanac) (*ConditionResult, error) {
 	var cacheKey string
 	var err error
 
 	result := &ConditionResult{
 		Fact:     c.Fact,
+		Rule:     c.Rule,
 		Operator: c.Operator,
 		Value:    c.Value,
 		Path:     c.Path,
 	}
 
 	// Check cache if enabled
-	if almanac.IsConditionCachingEnabled() {
+	cachingEnabled := conditionCachingEnabled(ctx, almanac)
+	if cachingEnabled {
 		cacheKey, err = c.GetCacheKey()
 		if err != nil {
 			return nil, &ConditionError{
 				Condition: *c,
-				Err:       fmt.Errorf("failed to get cache key for condition: %v", err),
+				Err:       fmt.Errorf("failed to get cache key for condition: %w", err),
 			}
 		}
 		if cachedVal, cached := almanac.GetConditionResultFromCache(cacheKey); cached {
@@ -245,39 +381,85 @@ func (c *Condition) Evaluate(almanac *Almanac) (*ConditionResult, error) {
 		}
 	}
 
+	// Presence operators read undefined facts and unresolved paths without error
+	operator, opErr := GetOperator(c.Operator)
+	presence, checksPresence := operator.(FactPresenceOperator)
+	status := FactFound
+
 	// Here params can be passed to the fact calculation
 	// Usefull only for dynamic facts
 	// For static facts, params are ignored
-	factValue, err := almanac.GetFactValue(c.Fact, c.Params, c.Path)
-	if err != nil {
-		return nil, &ConditionError{
-			Condition: *c,
-			Err:       fmt.Errorf("failed to get fact value: %v", err),
+	var factValue interface{}
+	if c.Rule != "" {
+		ruleResult, evaluated := almanac.GetRuleResult(c.Rule)
+		if !evaluated {
+			err = fmt.Errorf("rule '%s' has not been evaluated", c.Rule)
+			result.Error = err.Error()
+			return result, &ConditionError{
+				Condition: *c,
+				Err:       fmt.Errorf("failed to get rule result: %w", err),
+			}
+		}
+		factValue = ruleResult
+	} else {
+		if checksPresence {
+			factValue, status, err = resolveFactStatus(ctx, almanac, c.Fact, c.Params, c.Path)
+			result.FactStatus = status
+		} else {
+			factValue, err = resolveFactValue(ctx, almanac, c.Fact, c.Params, c.Path)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			return result, &ConditionError{
+				Condition: *c,
+				Err:       fmt.Errorf("failed to get fact value: %w", err),
+			}
 		}
 	}
 
 	result.FactValue = factValue
 
-	operator, err := GetOperator(c.Operator)
-	if err != nil {
-		return nil, &ConditionError{
+	compareValue := c.Value
+	if ref, ok := asFactReference(c.Value); ok {
+		compareValue, err = resolveFactValue(ctx, almanac, ref.Fact, ref.Params, ref.Path)
+		if err != nil {
+			result.Error = err.Error()
+			return result, &ConditionError{
+				Condition: *c,
+				Err:       fmt.Errorf("failed to get referenced fact value: %w", err),
+			}
+		}
+		result.CompareValue = compareValue
+	}
+
+	if opErr != nil {
+		result.Error = opErr.Error()
+		return result, &ConditionError{
 			Condition: *c,
-			Err:       fmt.Errorf("failed to get operator: %v", err),
+			Err:       fmt.Errorf("failed to get operator: %w", opErr),
 		}
 	}
 
-	evalRes, err := operator.Evaluate(factValue, c.Value)
+	var evalRes bool
+	if modal, ok := operator.(NumericModeOperator); ok {
+		evalRes, err = modal.EvaluateMode(factValue, compareValue, numericModeFromContext(ctx))
+	} else if checksPresence {
+		evalRes, err = presence.EvaluatePresence(status, factValue, compareValue)
+	} else {
+		evalRes, err = operator.Evaluate(factValue, compareValue)
+	}
 	if err != nil {
-		return nil, &ConditionError{
+		result.Error = err.Error()
+		return result, &ConditionError{
 			Condition: *c,
-			Err:       fmt.Errorf("operator evaluation failed: %v", err),
+			Err:       fmt.Errorf("operator evaluation failed: %w", err),
 		}
 	}
 
 	result.Result = evalRes
 
 	// Cache result if caching is enabled
-	if almanac.IsConditionCachingEnabled() && cacheKey != "" {
+	if cachingEnabled && cacheKey != "" {
 		almanac.SetConditionResultCache(cacheKey, result)
 	}
 
@@ -294,7 +476,7 @@ func (cs *ConditionSet) GetCacheKey() (string, error) {
 	if err != nil {
 		return "", &ConditionError{
 			Condition: Condition{},
-			Err:       fmt.Errorf("failed to marshal condition set for cache key: %v", err),
+			Err:       fmt.Errorf("failed to marshal condition set for cache key: %w", err),
 		}
 	}
 	sum := md5.Sum(bytes)
@@ -303,15 +485,26 @@ func (cs *ConditionSet) GetCacheKey() (string, error) {
 
 // Evaluate evaluates the condition set against the almanac
 func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error) {
+	return cs.EvaluateContext(context.Background(), almanac)
+}
+
+// EvaluateContext evaluates the condition set against the almanac.
+// The context is checked before each node and propagated to dynamic facts.
+func (cs *ConditionSet) EvaluateContext(ctx context.Context, almanac *Almanac) (*ConditionSetResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Check cache if enabled
 	var err error
 	var cacheKey string
-	if almanac.IsConditionCachingEnabled() {
+	cachingEnabled := conditionCachingEnabled(ctx, almanac)
+	if cachingEnabled {
 		cacheKey, err = cs.GetCacheKey()
 		if err != nil {
 			return nil, &ConditionError{
 				Condition: Condition{},
-				Err:       fmt.Errorf("failed to get cache key for condition set: %v", err),
+				Err:       fmt.Errorf("failed to get cache key for condition set: %w", err),
 			}
 		}
 		if cachedVal, cached := almanac.GetConditionResultFromCache(cacheKey); cached {
@@ -331,26 +524,26 @@ func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error)
 	anyNodes := cs.Any
 	noneNodes := cs.None
 
-	if almanac.IsConditionCachingEnabled() {
+	if cachingEnabled {
 		allNodes, err = cs.ReorderNodes(cs.All, almanac)
 		if err != nil {
 			return nil, &ConditionError{
 				Condition: Condition{},
-				Err:       fmt.Errorf("failed to reorder all nodes: %v", err),
+				Err:       fmt.Errorf("failed to reorder all nodes: %w", err),
 			}
 		}
 		anyNodes, err = cs.ReorderNodes(cs.Any, almanac)
 		if err != nil {
 			return nil, &ConditionError{
 				Condition: Condition{},
-				Err:       fmt.Errorf("failed to reorder any nodes: %v", err),
+				Err:       fmt.Errorf("failed to reorder any nodes: %w", err),
 			}
 		}
 		noneNodes, err = cs.ReorderNodes(cs.None, almanac)
 		if err != nil {
 			return nil, &ConditionError{
 				Condition: Condition{},
-				Err:       fmt.Errorf("failed to reorder none nodes: %v", err),
+				Err:       fmt.Errorf("failed to reorder none nodes: %w", err),
 			}
 		}
 	}
@@ -360,21 +553,20 @@ func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error)
 		result.Type = AllType
 		result.Result = true
 		for _, node := range allNodes {
-			nodeRes, err := evaluateConditionNode(&node, almanac)
+			nodeRes, err := evaluateConditionNode(ctx, &node, almanac)
 			if err != nil {
-				return nil, &ConditionError{
+				result.Result = false
+				if nodeRes != nil {
+					result.Results = append(result.Results, *nodeRes)
+				}
+				return result, &ConditionError{
 					Condition: Condition{},
-					Err:       fmt.Errorf("failed to evaluate all node: %v", err),
+					Err:       fmt.Errorf("failed to evaluate all node: %w", err),
 				}
 			}
 			result.Results = append(result.Results, *nodeRes)
 
-			var res bool
-			if nodeRes.Condition != nil {
-				res = nodeRes.Condition.Result
-			} else {
-				res = nodeRes.ConditionSet.Result
-			}
+			res := nodeRes.result()
 
 			if !res {
 				result.Result = false
@@ -385,21 +577,20 @@ func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error)
 		result.Type = AnyType
 		result.Result = false
 		for _, node := range anyNodes {
-			nodeRes, err := evaluateConditionNode(&node, almanac)
+			nodeRes, err := evaluateConditionNode(ctx, &node, almanac)
 			if err != nil {
-				return nil, &ConditionError{
+				result.Result = false
+				if nodeRes != nil {
+					result.Results = append(result.Results, *nodeRes)
+				}
+				return result, &ConditionError{
 					Condition: Condition{},
-					Err:       fmt.Errorf("failed to evaluate any node: %v", err),
+					Err:       fmt.Errorf("failed to evaluate any node: %w", err),
 				}
 			}
 			result.Results = append(result.Results, *nodeRes)
 
-			var res bool
-			if nodeRes.Condition != nil {
-				res = nodeRes.Condition.Result
-			} else {
-				res = nodeRes.ConditionSet.Result
-			}
+			res := nodeRes.result()
 
 			if res {
 				result.Result = true
@@ -410,21 +601,20 @@ func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error)
 		result.Type = NoneType
 		result.Result = true
 		for _, node := range noneNodes {
-			nodeRes, err := evaluateConditionNode(&node, almanac)
+			nodeRes, err := evaluateConditionNode(ctx, &node, almanac)
 			if err != nil {
-				return nil, &ConditionError{
+				result.Result = false
+				if nodeRes != nil {
+					result.Results = append(result.Results, *nodeRes)
+				}
+				return result, &ConditionError{
 					Condition: Condition{},
-					Err:       fmt.Errorf("failed to evaluate none node: %v", err),
+					Err:       fmt.Errorf("failed to evaluate none node: %w", err),
 				}
 			}
 			result.Results = append(result.Results, *nodeRes)
 
-			var res bool
-			if nodeRes.Condition != nil {
-				res = nodeRes.Condition.Result
-			} else {
-				res = nodeRes.ConditionSet.Result
-			}
+			res := nodeRes.result()
 
 			if res {
 				result.Result = false
@@ -433,7 +623,7 @@ func (cs *ConditionSet) Evaluate(almanac *Almanac) (*ConditionSetResult, error)
 		}
 	}
 
-	if almanac.IsConditionCachingEnabled() && cacheKey != "" {
+	if cachingEnabled && cacheKey != "" {
 		almanac.SetConditionResultCache(cacheKey, result)
 	}
 	return result, nil
@@ -455,7 +645,7 @@ func (cs *ConditionSet) ReorderNodes(nodes []ConditionNode, almanac *Almanac) ([
 			if err != nil {
 				return nil, &ConditionError{
 					Condition: *node.Condition,
-					Err:       fmt.Errorf("failed to get cache key for condition during reorder: %v", err),
+					Err:       fmt.Errorf("failed to get cache key for condition during reorder: %w", err),
 				}
 			}
 			_, isCached = almanac.GetConditionResultFromCache(key)
@@ -329,13 +329,12 @@ func TestConditionSetCompileErrors(t *testing.T) {
 	}
 
 	// THE COVERAGE TARGET: Error in cs.GetCacheKey() while child compilation succeeds
-	// By providing a node that has both Condition (valid) and SubSet (invalid)
-	// Compile will process Condition and skip SubSet, but json.Marshal will fail on SubSet
-	node := gre.ConditionNode{
-		Condition: &gre.Condition{Fact: "f", Operator: "equal", Value: 1},
-		SubSet:    &gre.ConditionSet{All: []gre.ConditionNode{{Condition: badCond}}},
-	}
-	cs5 := &gre.ConditionSet{All: []gre.ConditionNode{node}}
+	// By providing an empty node (neither Condition nor SubSet)
+	// Compile will skip it, but json.Marshal will fail on the invalid node
+	cs5 := &gre.ConditionSet{All: []gre.ConditionNode{
+		{Condition: &gre.Condition{Fact: "f", Operator: "equal", Value: 1}},
+		{},
+	}}
 	if err := cs5.Compile(); err == nil {
 		t.Error("Expected error in ConditionSet.Compile during GetCacheKey")
 	}
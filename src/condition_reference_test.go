@@ -0,0 +1,163 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCondition_FactReference(t *testing.T) {
+	ruleJSON := `{
+		"name": "over-daily-limit",
+		"conditions": {
+			"all": [
+				{"fact": "transaction", "path": "$.amount", "operator": "greater_than", "value": {"fact": "account", "path": "$.dailyLimit"}}
+			]
+		}
+	}`
+	var rule gre.Rule
+	if err := json.Unmarshal([]byte(ruleJSON), &rule); err != nil {
+		t.Fatalf("Failed to unmarshal rule: %v", err)
+	}
+
+	for _, tt := range []struct {
+		amount   float64
+		expected bool
+	}{{1500, true}, {500, false}} {
+		engine := gre.NewEngine(gre.WithAuditTrace())
+		engine.AddRule(&rule)
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("transaction", map[string]interface{}{"amount": tt.amount})
+		almanac.AddFact("account", map[string]interface{}{"dailyLimit": 1000.0})
+
+		res, err := engine.Evaluate(almanac)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		result := res.Results()["over-daily-limit"]
+		if result.Result != tt.expected {
+			t.Errorf("Amount %v: expected %v, got %v", tt.amount, tt.expected, result.Result)
+		}
+
+		cond := result.Conditions.Results[0].Condition
+		if cond.CompareValue != 1000.0 || cond.FactValue != tt.amount {
+			t.Errorf("Expected audit trace with resolved compare value, got %+v", cond)
+		}
+		if _, ok := cond.Value.(map[string]interface{}); !ok {
+			t.Errorf("Expected audit trace to keep the reference, got %v", cond.Value)
+		}
+	}
+}
+
+func TestCondition_FactReference_Builder(t *testing.T) {
+	cond := gre.NotEqual("shippingCountry", gre.FactRef("billingCountry", ""))
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("shippingCountry", "FR")
+	almanac.AddFact("billingCountry", "FR")
+
+	res, err := cond.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if res.Result {
+		t.Error("Expected equal countries not to match not_equal")
+	}
+
+	// Dynamic referenced facts receive the reference params
+	limit := &gre.FactReference{Fact: "limit", Params: map[string]interface{}{"tier": "gold"}}
+	almanac.AddFact("amount", 150)
+	almanac.AddFact("limit", func(params map[string]interface{}) interface{} {
+		if params["tier"] == "gold" {
+			return 200
+		}
+		return 100
+	})
+	res, err = gre.LessThan("amount", limit).Evaluate(almanac)
+	if err != nil || !res.Result {
+		t.Errorf("Expected amount under gold limit, got %+v, %v", res, err)
+	}
+
+	// A missing referenced fact is an error
+	res, err = gre.Equal("amount", gre.FactRef("missing", "")).Evaluate(almanac)
+	if err == nil || res.Error == "" {
+		t.Errorf("Expected error for a missing referenced fact, got %+v", res)
+	}
+}
+
+func TestCondition_FactReference_RequiredFactsAndCache(t *testing.T) {
+	rule := &gre.Rule{
+		Name:       "limit",
+		Conditions: gre.All(gre.GreaterThan("amount", gre.FactRef("account", "$.limit"))),
+	}
+
+	facts := rule.GetRequiredFacts()
+	if len(facts) != 2 {
+		t.Errorf("Expected the referenced fact to be required, got %v", facts)
+	}
+
+	// Smart skip ignores the rule when the referenced fact is missing
+	engine := gre.NewEngine(gre.WithSmartSkip())
+	engine.AddRule(rule)
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("amount", 10)
+	res, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected rule to be skipped, got %v", err)
+	}
+	if skipped := res.Results()["limit"]; skipped.Result || skipped.Error != "" {
+		t.Errorf("Expected rule to be skipped, got %+v", skipped)
+	}
+
+	// Conditions referencing different paths have different cache keys
+	key1, _ := gre.GreaterThan("amount", gre.FactRef("account", "$.limit")).GetCacheKey()
+	key2, _ := gre.GreaterThan("amount", gre.FactRef("account", "$.max")).GetCacheKey()
+	if key1 == key2 {
+		t.Error("Expected cache keys to include the reference")
+	}
+
+	cached := gre.NewAlmanac(gre.WithAlmanacConditionCaching())
+	cached.AddFact("amount", 10)
+	cached.AddFact("account", map[string]interface{}{"limit": 5, "max": 50})
+	r1, _ := gre.GreaterThan("amount", gre.FactRef("account", "$.limit")).Evaluate(cached)
+	r2, _ := gre.GreaterThan("amount", gre.FactRef("account", "$.max")).Evaluate(cached)
+	if !r1.Result || r2.Result {
+		t.Errorf("Expected cached results to stay distinct, got %v and %v", r1.Result, r2.Result)
+	}
+}
+
+func TestCondition_FactReference_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name: "refs",
+		Conditions: gre.All(
+			gre.GreaterThan("amount", map[string]interface{}{"fact": "account", "path": "$.limit"}),
+			gre.GreaterThan("amount", map[string]interface{}{"fact": "account", "other": 1}),
+			gre.Equal("amount", &gre.FactReference{}),
+		),
+	}
+
+	diags := gre.ValidateRule(rule)
+	if findDiagnostic(diags, gre.DiagnosticInvalidValue, "$.conditions.all[0].value") != nil {
+		t.Error("Expected fact reference not to be validated as a literal")
+	}
+	if findDiagnostic(diags, gre.DiagnosticInvalidValue, "$.conditions.all[1].value") == nil {
+		t.Errorf("Expected object with unknown keys to be a literal value, got %v", diags)
+	}
+	if findDiagnostic(diags, gre.DiagnosticMissingFact, "$.conditions.all[2].value.fact") == nil {
+		t.Errorf("Expected missing fact in reference, got %v", diags)
+	}
+}
+
+func TestCondition_FactReference_YAML(t *testing.T) {
+	var cond gre.Condition
+	err := yaml.Unmarshal([]byte("fact: transaction\npath: $.amount\noperator: greater_than\nvalue: {fact: account, path: $.dailyLimit, params: {currency: EUR}}\n"), &cond)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal condition: %v", err)
+	}
+	if facts := cond.GetRequiredFacts(); len(facts) != 2 || facts[1] != "account" {
+		t.Errorf("Expected YAML reference to be detected, got %v", facts)
+	}
+}
@@ -0,0 +1,112 @@
+package gorulesengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter is an event whose action still failed after its last attempt.
+type DeadLetter struct {
+	EventName string                 `json:"event"`
+	RuleName  string                 `json:"rule"`
+	Result    bool                   `json:"result"`           // Result of the rule that fired the event
+	Params    map[string]interface{} `json:"params,omitempty"` // Parameters the action was called with
+	Attempts  int                    `json:"attempts"`
+	Error     string                 `json:"error"` // Error of the last attempt
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// DeadLetterSink receives the events whose action failed, so they can be inspected or replayed.
+// Implementations must be safe for concurrent use.
+type DeadLetterSink interface {
+	Write(letter DeadLetter) error
+}
+
+// WithDeadLetterSink sends the events whose action still fails after its last
+// attempt to sink, for both sync and async events. A nil sink disables it.
+func WithDeadLetterSink(sink DeadLetterSink) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyDeadLetterSink] = sink
+	}
+}
+
+// MemoryDeadLetterSink keeps dead letters in memory.
+type MemoryDeadLetterSink struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+// NewMemoryDeadLetterSink creates an empty in-memory dead-letter sink.
+func NewMemoryDeadLetterSink() *MemoryDeadLetterSink {
+	return &MemoryDeadLetterSink{}
+}
+
+// Write stores the dead letter.
+func (s *MemoryDeadLetterSink) Write(letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+// Letters returns a copy of the stored dead letters, oldest first.
+func (s *MemoryDeadLetterSink) Letters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DeadLetter(nil), s.letters...)
+}
+
+// JSONLDeadLetterSink appends dead letters to a file, one JSON object per line.
+type JSONLDeadLetterSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewJSONLDeadLetterSink creates a sink appending to the file at path, which is
+// created on the first dead letter if needed.
+func NewJSONLDeadLetterSink(path string) *JSONLDeadLetterSink {
+	return &JSONLDeadLetterSink{path: path}
+}
+
+// Write appends the dead letter to the file.
+func (s *JSONLDeadLetterSink) Write(letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return &RuleEngineError{
+			Type: ErrJSON,
+			Msg:  fmt.Sprintf("cannot marshal the dead letter of event '%s'", letter.EventName),
+			Err:  err,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return &RuleEngineError{
+			Type: ErrEvent,
+			Msg:  fmt.Sprintf("cannot open dead-letter file '%s'", s.path),
+			Err:  err,
+		}
+	}
+
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return &RuleEngineError{
+			Type: ErrEvent,
+			Msg:  fmt.Sprintf("cannot write to dead-letter file '%s'", s.path),
+			Err:  err,
+		}
+	}
+	return file.Close()
+}
@@ -1,6 +1,8 @@
 package gorulesengine
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -31,6 +33,47 @@ const (
 	SortRuleDESC
 )
 
+const (
+	// EngineOptionKeyRuleValidation is the option key for rejecting invalid rules when they are added
+	EngineOptionKeyRuleValidation = "ruleValidation"
+	// EngineOptionKeyForwardChaining is the option key for enabling forward chaining
+	EngineOptionKeyForwardChaining = "forwardChaining"
+	// EngineOptionKeyMaxIterations is the option key for the maximum number of forward chaining passes
+	EngineOptionKeyMaxIterations = "maxIterations"
+	// EngineOptionKeyHitPolicy is the option key for the hit policy used by GenerateResponse
+	EngineOptionKeyHitPolicy = "hitPolicy"
+	// EngineOptionKeyStopOnFirstMatch is the option key for halting the run after the first matching rule
+	EngineOptionKeyStopOnFirstMatch = "stopOnFirstMatch"
+	// EngineOptionKeyErrorPolicy is the option key for the handling of rule evaluation and event errors
+	EngineOptionKeyErrorPolicy = "errorPolicy"
+	// EngineOptionKeyAsyncWorkers is the option key for the number of workers executing async events
+	EngineOptionKeyAsyncWorkers = "asyncWorkers"
+	// EngineOptionKeyAsyncQueueSize is the option key for the size of the async event queue
+	EngineOptionKeyAsyncQueueSize = "asyncQueueSize"
+	// EngineOptionKeyAsyncQueuePolicy is the option key for the handling of async events when the queue is full
+	EngineOptionKeyAsyncQueuePolicy = "asyncQueuePolicy"
+	// EngineOptionKeyDeadLetterSink is the option key for the sink receiving events whose action failed
+	EngineOptionKeyDeadLetterSink = "deadLetterSink"
+	// EngineOptionKeyNumericMode is the option key for the comparison of numbers of different types
+	EngineOptionKeyNumericMode = "numericMode"
+	// EngineOptionKeyEventParamResolution is the option key for resolving fact references and templates in event params
+	EngineOptionKeyEventParamResolution = "eventParamResolution"
+)
+
+// ErrorPolicy defines how a run handles the errors of a rule (condition evaluation or events).
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyAbort stops the run at the first error (default).
+	ErrorPolicyAbort ErrorPolicy = "abort"
+	// ErrorPolicySkipRule marks the rule as errored, without firing its events or
+	// setting its outcome for rule conditions, and continues with the next rules.
+	ErrorPolicySkipRule ErrorPolicy = "skip_rule"
+	// ErrorPolicyFalse treats the rule as failed: its OnFailure events fire and
+	// rule conditions see false. The run continues with the next rules.
+	ErrorPolicyFalse ErrorPolicy = "treat_as_false"
+)
+
 // Engine is the core rules engine that manages rules, facts, and event handlers.
 // It evaluates rules against facts and triggers events when rules match.
 type Engine struct {
@@ -57,6 +100,21 @@ type Engine struct {
 
 	// Additional engine options
 	options map[string]interface{}
+
+	// Bounded executor of async events, started on first use
+	dispatcher *asyncDispatcher
+
+	// Callback receiving the errors of async events
+	onAsyncError func(*AsyncEventError)
+
+	// Middleware chain wrapping the handling of every event
+	eventMiddleware []EventMiddleware
+
+	// Candidate rules evaluated in shadow of the active ones
+	shadowRules []*Rule
+
+	// Callback receiving the comparisons of the shadow and active rules
+	onShadowComparison func(ShadowComparison)
 }
 
 // EngineOption defines a function type for configuring the Engine.
@@ -197,6 +255,122 @@ func WithoutParallelExecution() EngineOption {
 	}
 }
 
+// WithRuleValidation makes AddRule, AddRules and SetRules reject rules whose
+// validation reports errors (see ValidateRule). Rejected rules are not added;
+// use TryAddRule, TryAddRules or TrySetRules to get the diagnostics.
+func WithRuleValidation() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyRuleValidation] = true
+	}
+}
+
+// WithoutRuleValidation disables the rejection of invalid rules.
+func WithoutRuleValidation() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyRuleValidation] = false
+	}
+}
+
+// WithForwardChaining enables forward chaining: facts asserted by rule events
+// (through Almanac.AddFact or the built-in setFact event) trigger the re-evaluation
+// of the rules requiring them, until no fact changes anymore.
+// maxIterations bounds the number of evaluation passes (10 if not positive).
+func WithForwardChaining(maxIterations int) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyForwardChaining] = true
+		e.options[EngineOptionKeyMaxIterations] = maxIterations
+	}
+}
+
+// WithoutForwardChaining disables forward chaining.
+func WithoutForwardChaining() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyForwardChaining] = false
+	}
+}
+
+// WithHitPolicy sets the hit policy deciding the outcome in GenerateResponse
+// (AnyMatchPolicy by default). A nil policy restores the default.
+func WithHitPolicy(policy HitPolicy) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyHitPolicy] = policy
+	}
+}
+
+// WithStopOnFirstMatch halts the run after the first rule that succeeds, in
+// evaluation order. The next rules are reported with RuleStatusNotEvaluated.
+func WithStopOnFirstMatch() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyStopOnFirstMatch] = true
+	}
+}
+
+// WithoutStopOnFirstMatch evaluates all rules, unless a rule or an event halts the run.
+func WithoutStopOnFirstMatch() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyStopOnFirstMatch] = false
+	}
+}
+
+// WithErrorPolicy sets how a run handles rule errors (ErrorPolicyAbort by default).
+// With ErrorPolicySkipRule or ErrorPolicyFalse, the run continues on error: each
+// error is reported in the RuleResult of its rule, and the run returns an ErrEngine
+// error joining them all, which supports errors.Is and errors.As.
+// A cancelled context still aborts the run.
+func WithErrorPolicy(policy ErrorPolicy) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyErrorPolicy] = policy
+	}
+}
+
 // NewEngine creates a new rules engine instance
 func NewEngine(opts ...EngineOption) *Engine {
 	e := &Engine{}
@@ -209,35 +383,116 @@ func NewEngine(opts ...EngineOption) *Engine {
 	return e
 }
 
-// AddRules adds multiple rules to the engine
+// AddRules adds multiple rules to the engine.
+// With WithRuleValidation, invalid rules are skipped.
+// Rules whose rule conditions would create a dependency cycle are always skipped.
 func (e *Engine) AddRules(rules ...*Rule) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	for _, rule := range rules {
-		rule.Compile()
+		if err := e.prepareRule(rule); err != nil && e.isRuleValidationEnabled() {
+			continue
+		}
+		candidate := append(e.rules[:len(e.rules):len(e.rules)], rule)
+		if checkRuleCycles(candidate) != nil {
+			continue
+		}
+		e.rules = candidate
 	}
-	e.rules = append(e.rules, rules...)
 }
 
-// AddRule adds a rule to the engine
+// AddRule adds a rule to the engine.
+// With WithRuleValidation, an invalid rule is not added.
 func (e *Engine) AddRule(rule *Rule) {
+	_ = e.TryAddRule(rule)
+}
+
+// SetRules replaces all rules in the engine with the provided ones.
+// With WithRuleValidation, the rules are not replaced if any of them is invalid.
+func (e *Engine) SetRules(rules []*Rule) {
+	_ = e.TrySetRules(rules)
+}
+
+// TryAddRule compiles and validates a rule, then adds it to the engine.
+// It returns a ValidationError holding the error diagnostics if the rule is invalid.
+// Without WithRuleValidation, an invalid rule is still added, as AddRule does.
+func (e *Engine) TryAddRule(rule *Rule) error {
+	return e.TryAddRules(rule)
+}
+
+// TryAddRules compiles and validates rules, then adds them to the engine.
+// It returns a ValidationError holding the error diagnostics of all invalid rules.
+// With WithRuleValidation, no rule is added if any of them is invalid.
+// No rule is added if their rule conditions would create a dependency cycle.
+func (e *Engine) TryAddRules(rules ...*Rule) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	rule.Compile()
-	e.rules = append(e.rules, rule)
+	err := e.prepareRules(rules)
+	if err != nil && e.isRuleValidationEnabled() {
+		return err
+	}
+	candidate := append(e.rules[:len(e.rules):len(e.rules)], rules...)
+	if cycleErr := checkRuleCycles(candidate); cycleErr != nil {
+		return cycleErr
+	}
+	e.rules = candidate
+	return err
 }
 
-// SetRules replaces all rules in the engine with the provided ones.
-func (e *Engine) SetRules(rules []*Rule) {
+// TrySetRules compiles and validates rules, then replaces all rules in the engine.
+// It returns a ValidationError holding the error diagnostics of all invalid rules.
+// With WithRuleValidation, the rules are not replaced if any of them is invalid.
+// The rules are not replaced if their rule conditions form a dependency cycle.
+func (e *Engine) TrySetRules(rules []*Rule) error {
+	_, err := e.replaceRules(rules)
+	return err
+}
+
+// replaceRules is TrySetRules, also reporting whether the rules were replaced.
+func (e *Engine) replaceRules(rules []*Rule) (bool, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	for _, rule := range rules {
-		rule.Compile()
+	err := e.prepareRules(rules)
+	if err != nil && e.isRuleValidationEnabled() {
+		return false, err
+	}
+	if cycleErr := checkRuleCycles(rules); cycleErr != nil {
+		return false, cycleErr
 	}
 	e.rules = rules
+	return true, err
+}
+
+// prepareRules compiles and validates rules, aggregating their error diagnostics.
+func (e *Engine) prepareRules(rules []*Rule) error {
+	var errs Diagnostics
+	for _, rule := range rules {
+		errs = append(errs, validateRuleForEngine(rule).Errors()...)
+	}
+	return errs.Err()
+}
+
+// checkRuleCycles returns a ValidationError if rule conditions form a dependency cycle.
+func checkRuleCycles(rules []*Rule) error {
+	if cycle := newRuleGraph(rules).findCycle(); cycle != nil {
+		return newRuleCycleError(cycle)
+	}
+	return nil
+}
+
+// prepareRule compiles and validates a single rule.
+func (e *Engine) prepareRule(rule *Rule) error {
+	return validateRuleForEngine(rule).Err()
+}
+
+// isRuleValidationEnabled reports whether invalid rules must be rejected.
+// The caller must hold the engine lock.
+func (e *Engine) isRuleValidationEnabled() bool {
+	enabled, ok := e.options[EngineOptionKeyRuleValidation].(bool)
+	return ok && enabled
 }
 
 // ClearRules removes all rules from the engine.
@@ -289,15 +544,47 @@ func (e *Engine) RegisterEvent(event Event) {
 	e.events[event.Name] = event
 }
 
-// Run executes all rules in the engine against the provided almanac.
-// Rules are evaluated in priority order (higher priority first).
-// Returns a slice of RuleResults containing the outcome of each rule evaluation.
-// If any error occurs during evaluation, execution stops and the error is returned.
-// Run executes all registered rules against the facts in the provided Almanac.
-// It returns a map of rule names to their evaluation results.
+// Run executes all registered rules against the facts in the provided Almanac
+// and stores the outcome on the engine, where it is available through Results,
+// ReduceResults and GenerateResponse.
+//
+// Run is kept for compatibility: because the outcome is stored on the shared
+// engine, concurrent calls overwrite each other's results. Use Evaluate when
+// the engine is shared across goroutines.
 func (e *Engine) Run(almanac *Almanac) (*Engine, error) {
-	startTime := time.Now()
+	return e.RunContext(context.Background(), almanac)
+}
+
+// RunContext is like Run but propagates ctx to condition evaluation, the parallel
+// worker pool, dynamic facts and event actions. When ctx is cancelled or its
+// deadline expires, the run is aborted with an ErrEngine error wrapping ctx.Err()
+// and the rules processed so far are kept as results.
+func (e *Engine) RunContext(ctx context.Context, almanac *Almanac) (*Engine, error) {
+	res, err := e.EvaluateContext(ctx, almanac)
+
+	e.mu.Lock()
+	e.results = res.results
+	e.almanac = res.almanac
+	e.mu.Unlock()
+
+	return e, err
+}
+
+// Evaluate executes all registered rules against the facts in the provided Almanac
+// and returns the outcome of this run as a RunResult.
+// Rules are evaluated in priority order (higher priority first).
+// If any error occurs during evaluation, execution stops and the error is returned
+// along with a RunResult holding the rules processed so far.
+// The engine itself is not modified, so Evaluate is safe for concurrent use.
+func (e *Engine) Evaluate(almanac *Almanac) (*RunResult, error) {
+	return e.EvaluateContext(context.Background(), almanac)
+}
 
+// EvaluateContext is like Evaluate but propagates ctx to condition evaluation,
+// the parallel worker pool, dynamic facts and event actions.
+// When ctx is done, the run is aborted with an ErrEngine error wrapping ctx.Err()
+// and the returned RunResult holds the partial result set.
+func (e *Engine) EvaluateContext(ctx context.Context, almanac *Almanac) (*RunResult, error) {
 	// Snapshot rules and options to ensure thread-safety during execution
 	e.mu.RLock()
 	rules := make([]*Rule, len(e.rules))
@@ -308,11 +595,47 @@ func (e *Engine) Run(almanac *Almanac) (*Engine, error) {
 		options[k] = v
 	}
 	metrics := e.metrics
+	shadow := e.shadowSnapshot()
 	e.mu.RUnlock()
 
+	// Simulated runs leave the metrics untouched and are not shadowed
+	if simulationFromContext(ctx) != nil {
+		metrics = nil
+		shadow = nil
+	}
+
+	// The shadow rules see the facts as they were before the run, and reuse the
+	// dynamic facts computed by the run
+	var shadowAlmanac *Almanac
+	if shadow != nil {
+		shadowAlmanac = almanac.fork()
+	}
+
+	run, err := e.evaluate(ctx, almanac, rules, options, metrics)
+
+	if shadow != nil {
+		e.compareShadow(ctx, shadow, run, shadowAlmanac, options)
+	}
+	return run, err
+}
+
+// evaluate runs the rules against the almanac with the given options.
+func (e *Engine) evaluate(ctx context.Context, almanac *Almanac, rules []*Rule, options map[string]interface{}, metrics MetricsCollector) (*RunResult, error) {
+	run := newRunResult(almanac)
+	sim := simulationFromContext(ctx)
+
+	run.policy, _ = options[EngineOptionKeyHitPolicy].(HitPolicy)
+	run.resolveParams, _ = options[EngineOptionKeyEventParamResolution].(bool)
+
+	// Events can halt the run through EventContext.Halt
+	ctx, _ = withRunHalt(ctx)
+	ctx = withNumericMode(ctx, numericModeOf(options))
+	ctx, events := withEventLog(ctx)
+
 	defer func() {
+		run.duration = time.Since(run.startedAt)
 		if metrics != nil {
-			metrics.ObserveEngineRun(len(rules), time.Since(startTime))
+			metrics.ObserveEngineRun(len(rules), run.duration)
 		}
 	}()
 
@@ -321,114 +644,130 @@ func (e *Engine) Run(almanac *Almanac) (*Engine, error) {
 		WithAlmanacConditionCaching()(almanac)
 	}
 
-	// Check for parallel execution
+	maxIterations, chaining := forwardChainingOptions(options)
+	if chaining {
+		almanac.startFactTracking()
+	}
+
+	err := e.runRules(ctx, run, rules, options, metrics)
+
+	// Re-evaluate the rules affected by asserted facts until a fixpoint is reached
+	if chaining {
+		if err == nil {
+			err = e.runForwardChaining(ctx, run, rules, options, metrics, maxIterations)
+		}
+		run.assertions = almanac.stopFactTracking()
+	}
+
+	// Errors collected when continuing on error
+	if err == nil && len(run.errs) > 0 {
+		err = &RuleEngineError{
+			Type: ErrEngine,
+			Msg:  fmt.Sprintf("%d error(s) occurred while evaluating rules", len(run.errs)),
+			Err:  errors.Join(run.errs...),
+		}
+	}
+
+	run.events = events.executions
+	if sim != nil {
+		run.simulated = true
+		run.simulatedEvents = sim.recorded()
+	}
+	run.err = err
+	return run, err
+}
+
+// runRules evaluates rules in parallel or sequentially, depending on the options.
+func (e *Engine) runRules(ctx context.Context, run *RunResult, rules []*Rule, options map[string]interface{}, metrics MetricsCollector) error {
 	if parallel, _ := options[EngineOptionKeyParallel].(bool); parallel {
-		return e.runParallel(almanac, rules, options)
+		return e.runParallel(ctx, run, rules, options, metrics)
 	}
+	return e.runSequential(ctx, run, rules, options, metrics)
+}
+
+// runSequential evaluates rules one after another, firing events as it goes.
+func (e *Engine) runSequential(ctx context.Context, run *RunResult, rules []*Rule, options map[string]interface{}, metrics MetricsCollector) error {
+	almanac := run.almanac
 
 	// Sort rules by priority if configured
 	e.sortRulesByPriority(rules, options)
 
-	var results = make(map[string]*RuleResult)
+	// Rules referenced by rule conditions are evaluated first
+	levels, err := orderRulesByDependencies(rules)
+	if err != nil {
+		return newDependencyError(err)
+	}
+
+	// Evaluate each rule in priority (then dependency) order
+	rules = flattenRuleLevels(levels)
+	for i, rule := range rules {
+		if err := ctx.Err(); err != nil {
+			return newCanceledError(err)
+		}
 
-	// Evaluate each rule in priority order
-	for _, rule := range rules {
 		// Check for smart skip if enabled
-		if skip, ok := options[EngineOptionKeySmartSkip].(bool); ok && skip {
-			requiredFacts := rule.GetRequiredFacts()
-			almanacFacts := almanac.GetFacts()
-			missingFact := false
-			for _, factID := range requiredFacts {
-				if _, exists := almanacFacts[factID]; !exists {
-					missingFact = true
-					break
-				}
-			}
-			if missingFact {
-				results[rule.Name] = &RuleResult{
-					Name:     rule.Name,
-					Priority: rule.Priority,
-					Result:   false,
-				}
-				if metrics != nil {
-					metrics.ObserveRuleEvaluation(rule.Name, false, 0)
-				}
-				continue
+		if shouldSkipRule(rule, almanac, options) {
+			run.results[rule.Name] = newSkippedRuleResult(rule, RuleStatusSkipped)
+			almanac.SetRuleResult(rule.Name, false)
+			if metrics != nil {
+				metrics.ObserveRuleEvaluation(rule.Name, false, 0)
 			}
+			continue
 		}
 
 		evalStart := time.Now()
 		// Evaluate rule conditions
-		condRes, err := rule.Conditions.Evaluate(almanac)
+		condRes, err := rule.Conditions.EvaluateContext(ctx, almanac)
 		evalDuration := time.Since(evalStart)
 
-		if metrics != nil {
-			metrics.ObserveRuleEvaluation(rule.Name, condRes.Result, evalDuration)
-		}
-
 		if err != nil {
-			e.results = results
-			return e, &RuleEngineError{
-				Type: ErrEngine,
-				Msg:  fmt.Sprintf("Error evaluating rule '%s': %v", rule.Name, err),
-				Err:  err,
+			if metrics != nil {
+				metrics.ObserveRuleEvaluation(rule.Name, false, evalDuration)
 			}
-		}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return newCanceledError(ctxErr)
+			}
+			run.results[rule.Name] = newErroredRuleResult(rule, condRes, err, options)
+			ruleErr := newRuleEvaluationError(rule, err)
 
-		ruleResult := &RuleResult{
-			Name:      rule.Name,
-			Priority:  rule.Priority,
-			Result:    condRes.Result,
-			OnSuccess: rule.OnSuccess,
-			OnFailure: rule.OnFailure,
+			policy := errorPolicyOf(options)
+			if policy == ErrorPolicyAbort {
+				return ruleErr
+			}
+			run.errs = append(run.errs, ruleErr)
+			if policy == ErrorPolicySkipRule {
+				run.results[rule.Name].Status = RuleStatusErrored
+				continue
+			}
+			condRes = &ConditionSetResult{Result: false}
+		} else {
+			if metrics != nil {
+				metrics.ObserveRuleEvaluation(rule.Name, condRes.Result, evalDuration)
+			}
+			run.results[rule.Name] = newRuleResult(rule, condRes, options)
 		}
 
-		// Add audit trace if enabled
-		if enabled, ok := options[EngineOptionKeyAuditTrace].(bool); ok && enabled {
-			ruleResult.Conditions = condRes
-		}
+		almanac.SetRuleResult(rule.Name, condRes.Result)
 
-		results[rule.Name] = ruleResult
+		if err := e.fireRuleEvents(ctx, run, rule, condRes.Result, options); err != nil {
+			return err
+		}
 
-		if condRes.Result {
-			// 1. Call OnSuccess event handlers
-			if rule.OnSuccess != nil {
-				for _, event := range rule.OnSuccess {
-					err = e.HandleEvent(event.Name, rule.Name, condRes.Result, almanac, event.Params)
-					if err != nil {
-						e.mu.Lock()
-						e.results = results
-						e.mu.Unlock()
-						return e, err
-					}
-				}
-			}
-		} else {
-			// 1. Call OnFailure event handlers
-			if rule.OnFailure != nil {
-				for _, event := range rule.OnFailure {
-					err = e.HandleEvent(event.Name, rule.Name, condRes.Result, almanac, event.Params)
-					if err != nil {
-						e.mu.Lock()
-						e.results = results
-						e.mu.Unlock()
-						return e, err
-					}
-				}
-			}
+		if shouldHaltAfter(ctx, rule, condRes.Result, options) {
+			run.halt(rule, rules[i+1:])
+			return nil
 		}
 	}
 
-	e.mu.Lock()
-	e.results = results
-	e.almanac = almanac
-	e.mu.Unlock()
-
-	return e, nil
+	return nil
 }
 
 // runParallel executes rules in parallel using a worker pool.
-func (e *Engine) runParallel(almanac *Almanac, rules []*Rule, options map[string]interface{}) (*Engine, error) {
+// Rules referenced by rule conditions are evaluated in an earlier wave than the
+// rules depending on them; events are fired afterwards, in order.
+func (e *Engine) runParallel(ctx context.Context, run *RunResult, rules []*Rule, options map[string]interface{}, metrics MetricsCollector) error {
+	almanac := run.almanac
+
 	workerCount, ok := options[EngineOptionKeyWorkerCount].(int)
 	if !ok || workerCount <= 0 {
 		workerCount = 1
@@ -437,10 +776,13 @@ func (e *Engine) runParallel(almanac *Almanac, rules []*Rule, options map[string
 	// Sort rules by priority if configured (important for event execution order)
 	e.sortRulesByPriority(rules, options)
 
+	levels, err := orderRulesByDependencies(rules)
+	if err != nil {
+		return newDependencyError(err)
+	}
+	rules = flattenRuleLevels(levels)
+
 	numRules := len(rules)
-	e.mu.RLock()
-	metrics := e.metrics
-	e.mu.RUnlock()
 
 	resultsChan := make(chan struct {
 		index    int
@@ -462,7 +804,7 @@ func (e *Engine) runParallel(almanac *Almanac, rules []*Rule, options map[string
 			defer wg.Done()
 			for task := range rulesChan {
 				start := time.Now()
-				res, err := task.rule.Conditions.Evaluate(almanac)
+				res, err := task.rule.Conditions.EvaluateContext(ctx, almanac)
 				duration := time.Since(start)
 				resultsChan <- struct {
 					index    int
@@ -474,210 +816,374 @@ func (e *Engine) runParallel(almanac *Almanac, rules []*Rule, options map[string
 		}()
 	}
 
-	// 2. Send rules (respecting smart skip)
-	for i, rule := range rules {
-		if skip, ok := options[EngineOptionKeySmartSkip].(bool); ok && skip {
-			requiredFacts := rule.GetRequiredFacts()
-			almanacFacts := almanac.GetFacts()
-			missingFact := false
-			for _, factID := range requiredFacts {
-				if _, exists := almanacFacts[factID]; !exists {
-					missingFact = true
-					break
-				}
-			}
-			if missingFact {
+	// 2. Send rules level by level (respecting smart skip) and collect results
+	orderedResults := make([]*ConditionSetResult, numRules)
+	orderedErrors := make([]error, numRules)
+	skipped := make([]bool, numRules)
+	var firstErr error
+	policy := errorPolicyOf(options)
+	offset := 0
+	for _, level := range levels {
+		if (firstErr != nil && policy == ErrorPolicyAbort) || ctx.Err() != nil {
+			break
+		}
+
+		for i, rule := range level {
+			if shouldSkipRule(rule, almanac, options) {
+				skipped[offset+i] = true
 				resultsChan <- struct {
 					index    int
 					res      *ConditionSetResult
 					err      error
 					duration time.Duration
-				}{i, &ConditionSetResult{Result: false}, nil, 0}
+				}{offset + i, &ConditionSetResult{Result: false}, nil, 0}
 				continue
 			}
+			rulesChan <- struct {
+				index int
+				rule  *Rule
+			}{offset + i, rule}
+		}
+
+		for range level {
+			r := <-resultsChan
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
+			}
+			orderedResults[r.index] = r.res
+			orderedErrors[r.index] = r.err
+			if metrics != nil && r.res != nil {
+				metrics.ObserveRuleEvaluation(rules[r.index].Name, r.res.Result, r.duration)
+			}
+			if r.err == nil && r.res != nil {
+				almanac.SetRuleResult(rules[r.index].Name, r.res.Result)
+			} else if r.err != nil && policy == ErrorPolicyFalse {
+				almanac.SetRuleResult(rules[r.index].Name, false)
+			}
 		}
-		rulesChan <- struct {
-			index int
-			rule  *Rule
-		}{i, rule}
+		offset += len(level)
 	}
 	close(rulesChan)
+	wg.Wait()
 
-	// Wait for workers in background and close results channel
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	// 3. Collect results
-	orderedResults := make([]*ConditionSetResult, numRules)
-	var firstErr error
-	for r := range resultsChan {
-		if r.err != nil && firstErr == nil {
-			firstErr = r.err
-		}
-		orderedResults[r.index] = r.res
-		if metrics != nil && r.res != nil {
-			metrics.ObserveRuleEvaluation(rules[r.index].Name, r.res.Result, r.duration)
+	// On cancellation, keep the rules that completed evaluation; no events are fired
+	if err := ctx.Err(); err != nil {
+		for i, rule := range rules {
+			if orderedResults[i] != nil && orderedErrors[i] == nil {
+				run.results[rule.Name] = newRuleResult(rule, orderedResults[i], options)
+			}
 		}
+		return newCanceledError(err)
 	}
 
-	if firstErr != nil {
-		return e, &RuleEngineError{
+	if firstErr != nil && policy == ErrorPolicyAbort {
+		for i, rule := range rules {
+			if orderedErrors[i] != nil {
+				run.results[rule.Name] = newErroredRuleResult(rule, orderedResults[i], orderedErrors[i], options)
+			}
+		}
+
+		return &RuleEngineError{
 			Type: ErrEngine,
 			Msg:  fmt.Sprintf("Parallel execution error: %v", firstErr),
 			Err:  firstErr,
 		}
 	}
 
-	// 4. Sequential event triggering (important for predictability)
-	results := make(map[string]*RuleResult)
+	// 3. Sequential event triggering (important for predictability).
+	// A halt discards the results of the rules evaluated ahead of it.
 	for i, rule := range rules {
+		if skipped[i] {
+			run.results[rule.Name] = newSkippedRuleResult(rule, RuleStatusSkipped)
+			continue
+		}
+
 		condRes := orderedResults[i]
 
-		ruleResult := &RuleResult{
-			Name:      rule.Name,
-			Priority:  rule.Priority,
-			Result:    condRes.Result,
-			OnSuccess: rule.OnSuccess,
-			OnFailure: rule.OnFailure,
+		if err := orderedErrors[i]; err != nil {
+			run.results[rule.Name] = newErroredRuleResult(rule, condRes, err, options)
+			run.errs = append(run.errs, newRuleEvaluationError(rule, err))
+			if policy == ErrorPolicySkipRule {
+				run.results[rule.Name].Status = RuleStatusErrored
+				continue
+			}
+			condRes = &ConditionSetResult{Result: false}
+		} else {
+			run.results[rule.Name] = newRuleResult(rule, condRes, options)
 		}
 
-		// Add audit trace if enabled
-		if enabled, ok := options[EngineOptionKeyAuditTrace].(bool); ok && enabled {
-			ruleResult.Conditions = condRes
+		if err := e.fireRuleEvents(ctx, run, rule, condRes.Result, options); err != nil {
+			return err
 		}
 
-		results[rule.Name] = ruleResult
+		if shouldHaltAfter(ctx, rule, condRes.Result, options) {
+			run.halt(rule, rules[i+1:])
+			return nil
+		}
+	}
 
-		if condRes.Result {
-			if rule.OnSuccess != nil {
-				for _, event := range rule.OnSuccess {
-					err := e.HandleEvent(event.Name, rule.Name, condRes.Result, almanac, event.Params)
-					if err != nil {
-						e.mu.Lock()
-						e.results = results
-						e.mu.Unlock()
-						return e, err
-					}
-				}
-			}
-		} else {
-			if rule.OnFailure != nil {
-				for _, event := range rule.OnFailure {
-					err := e.HandleEvent(event.Name, rule.Name, condRes.Result, almanac, event.Params)
-					if err != nil {
-						e.mu.Lock()
-						e.results = results
-						e.mu.Unlock()
-						return e, err
-					}
-				}
-			}
+	return nil
+}
+
+// flattenRuleLevels returns the rules of all dependency levels, in order.
+func flattenRuleLevels(levels [][]*Rule) []*Rule {
+	if len(levels) == 1 {
+		return levels[0]
+	}
+	var rules []*Rule
+	for _, level := range levels {
+		rules = append(rules, level...)
+	}
+	return rules
+}
+
+// newDependencyError creates the error returned when the rules of a run cannot
+// be ordered by their rule conditions.
+func newDependencyError(err error) error {
+	return &RuleEngineError{
+		Type: ErrEngine,
+		Msg:  "cannot order rules by their dependencies",
+		Err:  err,
+	}
+}
+
+// shouldSkipRule reports whether smart skip is enabled and the rule depends on
+// a fact that is not present in the almanac. Facts only checked by presence
+// operators (exists, not_exists...) may be missing.
+func shouldSkipRule(rule *Rule, almanac *Almanac, options map[string]interface{}) bool {
+	if skip, ok := options[EngineOptionKeySmartSkip].(bool); !ok || !skip {
+		return false
+	}
+
+	almanacFacts := almanac.GetFacts()
+	for _, factID := range requiredPresentFacts(&rule.Conditions) {
+		if _, exists := almanacFacts[factID]; !exists {
+			return true
 		}
 	}
+	return false
+}
 
-	e.mu.Lock()
-	e.results = results
-	e.almanac = almanac
-	e.mu.Unlock()
-	return e, nil
+// shouldHaltAfter reports whether the run must stop after a rule with the given
+// result: when an event halted it, when the rule's StopProcessing mode matches
+// the result, or on the first match with WithStopOnFirstMatch.
+func shouldHaltAfter(ctx context.Context, rule *Rule, result bool, options map[string]interface{}) bool {
+	if halt := runHaltFromContext(ctx); halt != nil && halt.requested.Load() {
+		return true
+	}
+
+	switch rule.StopProcessing {
+	case StopAlways:
+		return true
+	case StopOnSuccess:
+		if result {
+			return true
+		}
+	case StopOnFailure:
+		if !result {
+			return true
+		}
+	}
+
+	stop, _ := options[EngineOptionKeyStopOnFirstMatch].(bool)
+	return stop && result
 }
 
-// Results returns the detailed results of rule evaluations.
-func (e *Engine) Results() map[string]*RuleResult {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-	return e.results
+// newSkippedRuleResult builds the RuleResult of a rule that was not evaluated.
+func newSkippedRuleResult(rule *Rule, status RuleStatus) *RuleResult {
+	return &RuleResult{
+		Name:     rule.Name,
+		Priority: rule.Priority,
+		Result:   false,
+		Status:   status,
+	}
 }
 
-// ReduceResults converts a map of detailed RuleResults to a simple map of booleans.
-func (e *Engine) ReduceResults() map[string]bool {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+// newRuleResult builds the RuleResult of an evaluated rule.
+func newRuleResult(rule *Rule, condRes *ConditionSetResult, options map[string]interface{}) *RuleResult {
+	ruleResult := &RuleResult{
+		Name:      rule.Name,
+		Priority:  rule.Priority,
+		Result:    condRes.Result,
+		OnSuccess: rule.OnSuccess,
+		OnFailure: rule.OnFailure,
+		Effect:    rule.Effect,
+	}
 
-	reduced := make(map[string]bool, len(e.results))
-	for name, res := range e.results {
-		reduced[name] = res.Result
+	// Add audit trace if enabled
+	if enabled, ok := options[EngineOptionKeyAuditTrace].(bool); ok && enabled {
+		ruleResult.Conditions = condRes
 	}
-	return reduced
+
+	return ruleResult
 }
 
-// GenerateResponse builds the formatted Response object for JSON Marshalling.
-func (e *Engine) GenerateResponse() *EngineResponse {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+// newErroredRuleResult builds the RuleResult of a rule whose evaluation failed.
+// The partial audit trace, if enabled, shows the condition that caused the error.
+func newErroredRuleResult(rule *Rule, condRes *ConditionSetResult, err error, options map[string]interface{}) *RuleResult {
+	if condRes == nil {
+		condRes = &ConditionSetResult{}
+	}
+	ruleResult := newRuleResult(rule, condRes, options)
+	ruleResult.Result = false
+	ruleResult.addError(err)
+	return ruleResult
+}
 
-	res := &EngineResponse{
-		Decision: DecisionDecline,
-		Events:   []EventResponse{},
-		Metadata: make(map[string]interface{}),
+// addError appends err to the errors reported by the rule result.
+func (r *RuleResult) addError(err error) {
+	if r.Error != "" {
+		r.Error += "; "
 	}
+	r.Error += err.Error()
+}
 
-	if len(e.results) == 0 {
-		return res
+// newRuleEvaluationError wraps the error of a rule whose conditions could not be evaluated.
+func newRuleEvaluationError(rule *Rule, err error) error {
+	return &RuleEngineError{
+		Type: ErrEngine,
+		Msg:  fmt.Sprintf("Error evaluating rule '%s': %v", rule.Name, err),
+		Err:  err,
 	}
+}
 
-	// Extract metadata from Almanac if available
-	if e.almanac != nil {
-		facts := e.almanac.GetFacts()
-		for factID, fact := range facts {
-			meta := fact.Metadata()
-			if len(meta) > 0 {
-				res.Metadata[string(factID)] = meta
-			}
-		}
+// errorPolicyOf returns the error policy set in options. Unknown policies abort.
+func errorPolicyOf(options map[string]interface{}) ErrorPolicy {
+	switch policy, _ := options[EngineOptionKeyErrorPolicy].(ErrorPolicy); policy {
+	case ErrorPolicySkipRule, ErrorPolicyFalse:
+		return policy
 	}
+	return ErrorPolicyAbort
+}
 
-	var primaryResult *RuleResult
+// newCanceledError wraps the error of a done context into an engine error.
+func newCanceledError(err error) error {
+	return &RuleEngineError{
+		Type: ErrEngine,
+		Msg:  "evaluation canceled",
+		Err:  err,
+	}
+}
 
-	// Sort rules by priority if possible to determine the primary result
-	// Note: e.rules is already what we use in Run, so we use its order or max priority
-	for _, result := range e.results {
-		if primaryResult == nil || result.Priority > primaryResult.Priority {
-			primaryResult = result
-		}
+// fireRuleEvents fires the events of a rule during a run. Unless the error policy
+// is ErrorPolicyAbort, event errors are recorded in the rule result and the run
+// errors instead of being returned; a cancelled context is always returned.
+func (e *Engine) fireRuleEvents(ctx context.Context, run *RunResult, rule *Rule, result bool, options map[string]interface{}) error {
+	abort := errorPolicyOf(options) == ErrorPolicyAbort
 
-		if result.Result {
-			res.Decision = DecisionAuthorize
-			for _, ev := range result.OnSuccess {
-				res.Events = append(res.Events, EventResponse{
-					Type:   ev.Name,
-					Params: ev.Params,
-				})
-			}
-		} else {
-			for _, ev := range result.OnFailure {
-				res.Events = append(res.Events, EventResponse{
-					Type:   ev.Name,
-					Params: ev.Params,
-				})
-			}
-		}
+	err := e.handleRuleEvents(ctx, rule, result, run.almanac, abort)
+	if err == nil || abort {
+		return err
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return newCanceledError(ctxErr)
 	}
 
-	if primaryResult != nil {
-		if primaryResult.Conditions != nil {
-			res.Reason = primaryResult.Conditions
-		} else {
-			res.Reason = fmt.Sprintf("Rule '%s' determined the result", primaryResult.Name)
+	ruleErr := &RuleEngineError{
+		Type: ErrEvent,
+		Msg:  fmt.Sprintf("Error handling events of rule '%s'", rule.Name),
+		Err:  err,
+	}
+	run.results[rule.Name].addError(err)
+	run.errs = append(run.errs, ruleErr)
+	return nil
+}
+
+// handleRuleEvents fires the OnSuccess or OnFailure events of a rule depending on its result.
+// Facts asserted by the events are attributed to the rule when forward chaining.
+// Unless stopOnError is set, all the events are fired and their errors joined.
+func (e *Engine) handleRuleEvents(ctx context.Context, rule *Rule, result bool, almanac *Almanac, stopOnError bool) error {
+	events := rule.OnFailure
+	if result {
+		events = rule.OnSuccess
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	if almanac != nil {
+		almanac.trackRule(rule.Name)
+		defer almanac.trackRule("")
+	}
+
+	var errs []error
+	for _, event := range events {
+		if err := e.HandleEventContext(ctx, event.Name, rule.Name, result, almanac, event.Params); err != nil {
+			if stopOnError {
+				return err
+			}
+			errs = append(errs, err)
 		}
 	}
+	return errors.Join(errs...)
+}
 
-	return res
+// Results returns the detailed results of the last Run.
+func (e *Engine) Results() map[string]*RuleResult {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.results
+}
+
+// ReduceResults converts the results of the last Run to a simple map of booleans.
+func (e *Engine) ReduceResults() map[string]bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lastRun().ReduceResults()
+}
+
+// GenerateResponse builds the formatted Response object of the last Run for JSON Marshalling.
+func (e *Engine) GenerateResponse() *EngineResponse {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lastRun().GenerateResponse()
+}
+
+// lastRun wraps the results stored by the last Run in a RunResult.
+// The caller must hold the engine lock.
+func (e *Engine) lastRun() *RunResult {
+	policy, _ := e.options[EngineOptionKeyHitPolicy].(HitPolicy)
+	return &RunResult{
+		results:       e.results,
+		almanac:       e.almanac,
+		policy:        policy,
+		resolveParams: e.options[EngineOptionKeyEventParamResolution] == true,
+	}
 }
 
 // HandleEvent invokes the event handler for the given event with context.
 // Supports both synchronous and asynchronous execution based on event mode.
 // ruleParams are optional parameters passed from the rule itself and combined with event defaults.
 func (e *Engine) HandleEvent(eventName string, ruleName string, result bool, almanac *Almanac, ruleParams map[string]interface{}) error {
+	return e.HandleEventContext(context.Background(), eventName, ruleName, result, almanac, ruleParams)
+}
+
+// HandleEventContext is like HandleEvent but exposes ctx to the event action and
+// global handler through EventContext.Context. A synchronous event is not executed
+// if ctx is already done.
+func (e *Engine) HandleEventContext(runCtx context.Context, eventName string, ruleName string, result bool, almanac *Almanac, ruleParams map[string]interface{}) error {
 	e.mu.RLock()
 	event, exists := e.events[eventName]
 	handlerHost := e.eventHandler
 	metrics := e.metrics
+	sink, _ := e.options[EngineOptionKeyDeadLetterSink].(DeadLetterSink)
+	resolveParams, _ := e.options[EngineOptionKeyEventParamResolution].(bool)
+	middleware := e.eventMiddleware
 	e.mu.RUnlock()
 
 	if !exists {
+		if eventName == SetFactEventName {
+			value, err := assertFactFromParams(runCtx, almanac, ruleParams, resolveParams)
+			if sim := simulationFromContext(runCtx); sim != nil && err == nil {
+				sim.record(SimulatedEvent{
+					EventName: eventName,
+					RuleName:  ruleName,
+					Result:    result,
+					Params:    map[string]interface{}{"fact": ruleParams["fact"], "value": value},
+				})
+			}
+			return err
+		}
 		if handlerHost != nil {
 			return &RuleEngineError{
 				Type: ErrEngine,
@@ -697,66 +1203,88 @@ func (e *Engine) HandleEvent(eventName string, ruleName string, result bool, alm
 		finalParams[k] = v
 	}
 
+	// Replace fact references and templates by their values
+	if resolveParams {
+		var err error
+		finalParams, err = resolveEventParams(runCtx, almanac, finalParams)
+		if err != nil {
+			return &RuleEngineError{
+				Type: ErrEvent,
+				Msg:  fmt.Sprintf("cannot resolve the params of event '%s'", eventName),
+				Err:  err,
+			}
+		}
+	}
+
+	// Record the event instead of dispatching it when simulating
+	if sim := simulationFromContext(runCtx); sim != nil {
+		sim.record(SimulatedEvent{
+			EventName: eventName,
+			RuleName:  ruleName,
+			Result:    result,
+			Async:     event.Mode == EventModeAsync,
+			Params:    finalParams,
+		})
+		return nil
+	}
+
 	// Build event context
 	ctx := EventContext{
+		Context:   runCtx,
 		RuleName:  ruleName,
 		Result:    result,
 		Almanac:   almanac,
 		Timestamp: time.Now(),
 		Params:    finalParams,
+		halt:      runHaltFromContext(runCtx),
 	}
 
 	// Handle async events
 	if event.Mode == EventModeAsync {
-		go func() {
-			start := time.Now()
-			// Execute action if defined
-			if event.Action != nil {
-				_ = event.Action(ctx)
-			}
-			// Execute global handler if defined
-			if handlerHost != nil {
-				_ = handlerHost.Handle(event, ctx)
-			}
-
-			if metrics != nil {
-				metrics.ObserveEventExecution(eventName, ruleName, result, time.Since(start))
-			}
-		}()
-		return nil
+		return e.dispatchAsyncEvent(event, ctx, handlerHost, metrics, sink, middleware)
 	}
 
 	// Handle sync events
-	var err error
+	if err := runCtx.Err(); err != nil {
+		return newCanceledError(err)
+	}
+
 	start := time.Now()
+	execution := EventExecution{EventName: eventName, RuleName: ruleName}
 
-	// Execute action if defined
-	if event.Action != nil {
-		if err = event.Action(ctx); err != nil {
+	handle := func(event Event, ctx EventContext) error {
+		// Execute action if defined, with retries
+		if err := e.runEventAction(event, ctx, sink, &execution); err != nil {
 			return &RuleEngineError{
 				Type: ErrEngine,
 				Msg:  fmt.Sprintf("Error executing action for event '%s': %v", eventName, err),
 				Err:  err,
 			}
 		}
-	}
 
-	// Execute global handler if defined
-	if handlerHost != nil {
-		if err = handlerHost.Handle(event, ctx); err != nil {
-			return &RuleEngineError{
-				Type: ErrEngine,
-				Msg:  fmt.Sprintf("Error in Event %s : \n %v", eventName, err),
-				Err:  err,
+		// Execute global handler if defined
+		if handlerHost != nil {
+			if err := handlerHost.Handle(event, ctx); err != nil {
+				return &RuleEngineError{
+					Type: ErrEngine,
+					Msg:  fmt.Sprintf("Error in Event %s : \n %v", eventName, err),
+					Err:  err,
+				}
 			}
 		}
+		return nil
 	}
 
-	if metrics != nil {
-		metrics.ObserveEventExecution(eventName, ruleName, result, time.Since(start))
+	err := chainEventMiddleware(middleware, handle)(event, ctx)
+
+	execution.Duration = time.Since(start)
+	if err != nil {
+		execution.Error = err.Error()
 	}
+	observeEventExecution(metrics, execution, result)
+	recordEventExecution(runCtx, execution)
 
-	return nil
+	return err
 }
 
 // sortRulesByPriority sorts the engine's rules by their priority in descending order.
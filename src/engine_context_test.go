@@ -0,0 +1,276 @@
+package gorulesengine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// slowFact returns a context-aware dynamic fact that waits for delay or ctx, whichever comes first.
+func slowFact(delay time.Duration, value interface{}) func(context.Context, map[string]interface{}) (interface{}, error) {
+	return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		select {
+		case <-time.After(delay):
+			return value, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestEngine_RunContext(t *testing.T) {
+	newEngine := func(opts ...gre.EngineOption) *gre.Engine {
+		engine := gre.NewEngine(opts...)
+		engine.AddRule(&gre.Rule{
+			Name:       "fast",
+			Priority:   10,
+			Conditions: gre.All(gre.Equal("fast", true)),
+		})
+		engine.AddRule(&gre.Rule{
+			Name:       "slow",
+			Priority:   1,
+			Conditions: gre.All(gre.Equal("slow", true)),
+		})
+		return engine
+	}
+
+	newAlmanac := func() *gre.Almanac {
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("fast", true)
+		almanac.AddFact("slow", slowFact(time.Second, true))
+		return almanac
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("aborts on deadline with partial results (parallel=%v)", parallel), func(t *testing.T) {
+			var opts []gre.EngineOption
+			if parallel {
+				opts = append(opts, gre.WithParallelExecution(2))
+			}
+			engine := newEngine(opts...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			res, err := engine.EvaluateContext(ctx, newAlmanac())
+			if time.Since(start) > 500*time.Millisecond {
+				t.Errorf("Expected evaluation to stop at the deadline, took %v", time.Since(start))
+			}
+
+			var ruleErr *gre.RuleEngineError
+			if !errors.As(err, &ruleErr) || ruleErr.Type != gre.ErrEngine {
+				t.Fatalf("Expected ErrEngine error, got %v", err)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+			}
+			if !res.ReduceResults()["fast"] {
+				t.Error("Expected completed rule 'fast' in partial results")
+			}
+			if _, ok := res.Results()["slow"]; ok {
+				t.Error("Expected aborted rule 'slow' to be absent from results")
+			}
+		})
+	}
+
+	t.Run("does not evaluate when context is already cancelled", func(t *testing.T) {
+		engine := newEngine()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		e, err := engine.RunContext(ctx, newAlmanac())
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+		if len(e.Results()) != 0 {
+			t.Errorf("Expected no results, got %v", e.Results())
+		}
+	})
+
+	t.Run("completes when context is not done", func(t *testing.T) {
+		engine := newEngine()
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("fast", true)
+		almanac.AddFact("slow", slowFact(time.Millisecond, true))
+
+		e, err := engine.RunContext(context.Background(), almanac)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if results := e.ReduceResults(); !results["fast"] || !results["slow"] {
+			t.Errorf("Expected both rules to succeed, got %v", results)
+		}
+	})
+}
+
+func TestEngine_RunContext_Events(t *testing.T) {
+	type ctxKey string
+
+	t.Run("propagates context to sync and async actions", func(t *testing.T) {
+		engine := gre.NewEngine()
+		received := make(chan context.Context, 2)
+		engine.RegisterEvents(
+			gre.Event{Name: "sync", Action: func(ec gre.EventContext) error {
+				received <- ec.Context
+				return nil
+			}},
+			gre.Event{Name: "async", Mode: gre.EventModeAsync, Action: func(ec gre.EventContext) error {
+				received <- ec.Context
+				return nil
+			}},
+		)
+		engine.AddRule(&gre.Rule{
+			Name:       "rule",
+			Conditions: gre.All(gre.Equal("ok", true)),
+			OnSuccess:  []gre.RuleEvent{{Name: "sync"}, {Name: "async"}},
+		})
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("ok", true)
+
+		ctx := context.WithValue(context.Background(), ctxKey("request"), "abc")
+		if _, err := engine.RunContext(ctx, almanac); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			select {
+			case got := <-received:
+				if got.Value(ctxKey("request")) != "abc" {
+					t.Errorf("Expected run context in event, got %v", got)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Timed out waiting for event")
+			}
+		}
+	})
+
+	t.Run("does not execute sync events once cancelled", func(t *testing.T) {
+		engine := gre.NewEngine()
+		called := false
+		engine.RegisterEvent(gre.Event{Name: "sync", Action: func(ec gre.EventContext) error {
+			called = true
+			return nil
+		}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := engine.HandleEventContext(ctx, "sync", "rule", true, gre.NewAlmanac(), nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if called {
+			t.Error("Expected action not to be called")
+		}
+	})
+}
+
+func TestAlmanac_GetFactValueContext(t *testing.T) {
+	t.Run("wraps dynamic fact errors in a FactError", func(t *testing.T) {
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("db unavailable")
+		})
+
+		_, err := almanac.GetFactValueContext(context.Background(), "score", nil, "")
+		var factErr *gre.FactError
+		if !errors.As(err, &factErr) {
+			t.Fatalf("Expected FactError, got %v", err)
+		}
+		if factErr.Fact.ID() != "score" {
+			t.Errorf("Expected fact 'score', got %s", factErr.Fact.ID())
+		}
+	})
+
+	t.Run("does not cache failed computations", func(t *testing.T) {
+		calls := 0
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("transient")
+			}
+			return 10, nil
+		})
+
+		if _, err := almanac.GetFactValueContext(context.Background(), "score", nil, ""); err == nil {
+			t.Fatal("Expected first call to fail")
+		}
+		val, err := almanac.GetFactValueContext(context.Background(), "score", nil, "")
+		if err != nil || val != 10 {
+			t.Errorf("Expected 10 and no error, got %v, %v", val, err)
+		}
+	})
+
+	t.Run("returns the context error before computing", func(t *testing.T) {
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("score", slowFact(time.Second, 1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := almanac.GetFactValueContext(ctx, "score", nil, "")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestEngine_FactErrors(t *testing.T) {
+	newEngine := func(opts ...gre.EngineOption) *gre.Engine {
+		engine := gre.NewEngine(opts...)
+		engine.AddRule(&gre.Rule{
+			Name:       "balance-check",
+			Conditions: gre.All(gre.GreaterThan("balance", 100)),
+		})
+		return engine
+	}
+
+	newAlmanac := func() *gre.Almanac {
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("balance", func(params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("db unavailable")
+		})
+		return almanac
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("surfaces FactError in error path and audit trace (parallel=%v)", parallel), func(t *testing.T) {
+			opts := []gre.EngineOption{gre.WithAuditTrace()}
+			if parallel {
+				opts = append(opts, gre.WithParallelExecution(2))
+			}
+
+			res, err := newEngine(opts...).Evaluate(newAlmanac())
+
+			var factErr *gre.FactError
+			if !errors.As(err, &factErr) {
+				t.Fatalf("Expected FactError in the error chain, got %v", err)
+			}
+			if factErr.Fact.ID() != "balance" {
+				t.Errorf("Expected fact 'balance', got %s", factErr.Fact.ID())
+			}
+
+			ruleRes := res.Results()["balance-check"]
+			if ruleRes == nil {
+				t.Fatal("Expected errored rule to be reported")
+			}
+			if ruleRes.Result || ruleRes.Error == "" {
+				t.Errorf("Expected rule to be false with an error, got %+v", ruleRes)
+			}
+			if ruleRes.Conditions == nil || len(ruleRes.Conditions.Results) != 1 {
+				t.Fatalf("Expected audit trace with the failing condition, got %+v", ruleRes.Conditions)
+			}
+			condRes := ruleRes.Conditions.Results[0].Condition
+			if condRes == nil || condRes.Error == "" {
+				t.Errorf("Expected condition error in audit trace, got %+v", condRes)
+			}
+		})
+	}
+}
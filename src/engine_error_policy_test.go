@@ -0,0 +1,230 @@
+package gorulesengine_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+var errScoreUnavailable = errors.New("score service unavailable")
+
+// evaluateErrorPolicy runs rules whose score fact, amount comparison or notify
+// event fail, and returns the run, its error and the "record" events fired, as
+// "rule:result".
+func evaluateErrorPolicy(t *testing.T, opts ...gre.EngineOption) (*gre.RunResult, error, []string) {
+	t.Helper()
+
+	engine := gre.NewEngine(opts...)
+	var fired []string
+	engine.RegisterEvents(
+		gre.Event{
+			Name: "record",
+			Action: func(ctx gre.EventContext) error {
+				result := "false"
+				if ctx.Result {
+					result = "true"
+				}
+				fired = append(fired, ctx.RuleName+":"+result)
+				return nil
+			},
+		},
+		gre.Event{
+			Name: "notify",
+			Action: func(ctx gre.EventContext) error {
+				return errors.New("mailer down")
+			},
+		},
+	)
+	rules := []*gre.Rule{
+		{
+			Name:       "high-score",
+			Priority:   40,
+			Conditions: gre.All(gre.GreaterThan("score", 700)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+			OnFailure:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "amount",
+			Priority:   30,
+			Conditions: gre.All(gre.GreaterThan("amount", 0)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+			OnFailure:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "country",
+			Priority:   20,
+			Conditions: gre.All(gre.Equal("country", "FR")),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}, {Name: "notify"}},
+		},
+		{
+			Name:       "not-high-score",
+			Priority:   10,
+			Conditions: gre.All(&gre.Condition{Rule: "high-score", Operator: gre.OperatorEqual, Value: false}),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+	}
+	if err := engine.TryAddRules(rules...); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("score", func() (interface{}, error) {
+		return nil, errScoreUnavailable
+	})
+	almanac.AddFact("amount", "abc")
+	almanac.AddFact("country", "FR")
+
+	run, err := engine.Evaluate(almanac)
+	return run, err, fired
+}
+
+func TestErrorPolicy_Abort(t *testing.T) {
+	for name, opts := range map[string][]gre.EngineOption{
+		"default":  nil,
+		"explicit": {gre.WithErrorPolicy(gre.ErrorPolicyAbort)},
+		"unknown":  {gre.WithErrorPolicy("retry")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			run, err, fired := evaluateErrorPolicy(t, opts...)
+
+			if !errors.Is(err, errScoreUnavailable) {
+				t.Fatalf("Expected the score error, got %v", err)
+			}
+			if len(fired) != 0 {
+				t.Errorf("Expected no events, got %v", fired)
+			}
+			if len(run.Results()) != 1 {
+				t.Errorf("Expected only the failing rule in the results, got %v", run.Results())
+			}
+		})
+	}
+}
+
+func TestErrorPolicy_SkipRule(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			run, err, fired := evaluateErrorPolicy(t, append(opts, gre.WithErrorPolicy(gre.ErrorPolicySkipRule))...)
+
+			if !reflect.DeepEqual(fired, []string{"country:true"}) {
+				t.Errorf("Expected only country to fire, got %v", fired)
+			}
+
+			results := run.Results()
+			for _, name := range []string{"high-score", "amount", "not-high-score"} {
+				res := results[name]
+				if res.Status != gre.RuleStatusErrored || res.Result || res.Error == "" {
+					t.Errorf("Expected %s to be errored, got %+v", name, res)
+				}
+			}
+			if res := results["country"]; res.Status != "" || !res.Result || !strings.Contains(res.Error, "mailer down") {
+				t.Errorf("Expected country to pass with its event error, got %+v", res)
+			}
+
+			assertCollectedErrors(t, err, run, 4)
+		})
+	}
+}
+
+func TestErrorPolicy_SkipRuleResponse(t *testing.T) {
+	// The events of an errored rule were not fired, so the response does not list them
+	engine := gre.NewEngine(gre.WithErrorPolicy(gre.ErrorPolicySkipRule))
+	calls := 0
+	engine.RegisterEvent(gre.Event{
+		Name:   "rejected",
+		Action: func(ctx gre.EventContext) error { calls++; return nil },
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "high-score",
+		Conditions: gre.All(gre.GreaterThan("score", 700)),
+		OnSuccess:  []gre.RuleEvent{{Name: "accepted"}},
+		OnFailure:  []gre.RuleEvent{{Name: "rejected"}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("score", func() (interface{}, error) {
+		return nil, errScoreUnavailable
+	})
+
+	run, err := engine.Evaluate(almanac)
+	if !errors.Is(err, errScoreUnavailable) {
+		t.Fatalf("Expected the score error, got %v", err)
+	}
+	if calls != 0 || run.Results()["high-score"].Status != gre.RuleStatusErrored {
+		t.Fatalf("Expected an errored rule without events, got %d call(s) and %+v", calls, run.Results()["high-score"])
+	}
+	if events := run.GenerateResponse().Events; len(events) != 0 {
+		t.Errorf("Expected no events in the response, got %+v", events)
+	}
+}
+
+func TestErrorPolicy_TreatAsFalse(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			run, err, fired := evaluateErrorPolicy(t, append(opts, gre.WithErrorPolicy(gre.ErrorPolicyFalse))...)
+
+			expected := []string{"high-score:false", "amount:false", "country:true", "not-high-score:true"}
+			if !reflect.DeepEqual(fired, expected) {
+				t.Errorf("Expected %v, got %v", expected, fired)
+			}
+
+			results := run.Results()
+			for _, name := range []string{"high-score", "amount"} {
+				res := results[name]
+				if res.Status != "" || res.Result || res.Error == "" {
+					t.Errorf("Expected %s to fail with an error, got %+v", name, res)
+				}
+			}
+			if res := results["not-high-score"]; !res.Result || res.Error != "" {
+				t.Errorf("Expected not-high-score to see high-score as false, got %+v", res)
+			}
+
+			assertCollectedErrors(t, err, run, 3)
+		})
+	}
+}
+
+// assertCollectedErrors checks the aggregated error of a run that continued on error.
+func assertCollectedErrors(t *testing.T, err error, run *gre.RunResult, count int) {
+	t.Helper()
+
+	var engineErr *gre.RuleEngineError
+	if !errors.As(err, &engineErr) || engineErr.Type != gre.ErrEngine {
+		t.Fatalf("Expected an ErrEngine error, got %v", err)
+	}
+	if run.Err() != err {
+		t.Errorf("Expected the run error to be %v, got %v", err, run.Err())
+	}
+
+	joined, ok := engineErr.Err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != count {
+		t.Fatalf("Expected %d collected errors, got %v", count, engineErr.Err)
+	}
+
+	if !errors.Is(err, errScoreUnavailable) {
+		t.Error("Expected errors.Is to find the fact error")
+	}
+	var condErr *gre.ConditionError
+	if !errors.As(err, &condErr) {
+		t.Error("Expected errors.As to find a ConditionError")
+	}
+	var opErr *gre.OperatorError
+	if !errors.As(err, &opErr) || opErr.Operator != gre.OperatorGreaterThan {
+		t.Errorf("Expected errors.As to find the greater_than OperatorError, got %v", opErr)
+	}
+	if !strings.Contains(err.Error(), "mailer down") {
+		t.Errorf("Expected the event error in %q", err.Error())
+	}
+}
@@ -0,0 +1,327 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// evaluateHalt runs rules against a standard member buying for 100 and returns
+// the run and the rules whose events fired.
+func evaluateHalt(t *testing.T, rules []*gre.Rule, action func(gre.EventContext), opts ...gre.EngineOption) (*gre.RunResult, []string) {
+	t.Helper()
+
+	engine := gre.NewEngine(opts...)
+	var fired []string
+	engine.RegisterEvent(gre.Event{
+		Name: "record",
+		Action: func(ctx gre.EventContext) error {
+			fired = append(fired, ctx.RuleName)
+			if action != nil {
+				action(ctx)
+			}
+			return nil
+		},
+	})
+	if err := engine.TryAddRules(rules...); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("tier", "standard")
+	almanac.AddFact("member", true)
+	almanac.AddFact("amount", 100)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return run, fired
+}
+
+func TestStopOnFirstMatch(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			rules := []*gre.Rule{
+				{
+					Name:       "vip-price",
+					Priority:   30,
+					Conditions: gre.All(gre.Equal("tier", "vip")),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "member-price",
+					Priority:   20,
+					Conditions: gre.All(gre.Equal("member", true)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "default-price",
+					Priority:   10,
+					Conditions: gre.All(gre.GreaterThan("amount", 0)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+			}
+
+			run, fired := evaluateHalt(t, rules, nil, append(opts, gre.WithStopOnFirstMatch())...)
+
+			if !reflect.DeepEqual(fired, []string{"member-price"}) {
+				t.Errorf("Expected only member-price to fire, got %v", fired)
+			}
+			if run.HaltedBy() != "member-price" {
+				t.Errorf("Expected the run to halt after member-price, got %q", run.HaltedBy())
+			}
+
+			results := run.Results()
+			if res := results["vip-price"]; res.Status != "" || res.Result {
+				t.Errorf("Expected vip-price to be evaluated and fail, got %+v", res)
+			}
+			if res := results["default-price"]; res.Status != gre.RuleStatusNotEvaluated || res.Result {
+				t.Errorf("Expected default-price not to be evaluated, got %+v", res)
+			}
+
+			expected := map[string]bool{"vip-price": false, "member-price": true}
+			if reduced := run.ReduceResults(); !reflect.DeepEqual(reduced, expected) {
+				t.Errorf("Expected %v, got %v", expected, reduced)
+			}
+		})
+	}
+}
+
+func TestStopOnFirstMatch_Disabled(t *testing.T) {
+	rules := []*gre.Rule{
+		{
+			Name:       "vip-price",
+			Priority:   30,
+			Conditions: gre.All(gre.Equal("tier", "vip")),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "member-price",
+			Priority:   20,
+			Conditions: gre.All(gre.Equal("member", true)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "default-price",
+			Priority:   10,
+			Conditions: gre.All(gre.GreaterThan("amount", 0)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+	}
+
+	run, fired := evaluateHalt(t, rules, nil, gre.WithStopOnFirstMatch(), gre.WithoutStopOnFirstMatch())
+
+	if len(fired) != 2 {
+		t.Errorf("Expected 2 events, got %v", fired)
+	}
+	if run.HaltedBy() != "" {
+		t.Errorf("Expected the run not to halt, got %q", run.HaltedBy())
+	}
+}
+
+func TestRuleStopProcessing(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		mode     gre.StopMode
+		haltedBy string
+		fired    []string
+	}{
+		{"success on matching rule", "member-price", gre.StopOnSuccess, "member-price", []string{"member-price"}},
+		{"success on failing rule", "vip-price", gre.StopOnSuccess, "", []string{"member-price", "default-price"}},
+		{"failure on failing rule", "vip-price", gre.StopOnFailure, "vip-price", nil},
+		{"failure on matching rule", "member-price", gre.StopOnFailure, "", []string{"member-price", "default-price"}},
+		{"always", "vip-price", gre.StopAlways, "vip-price", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := []*gre.Rule{
+				{
+					Name:       "vip-price",
+					Priority:   30,
+					Conditions: gre.All(gre.Equal("tier", "vip")),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "member-price",
+					Priority:   20,
+					Conditions: gre.All(gre.Equal("member", true)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "default-price",
+					Priority:   10,
+					Conditions: gre.All(gre.GreaterThan("amount", 0)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+			}
+			for _, rule := range rules {
+				if rule.Name == tt.rule {
+					rule.StopProcessing = tt.mode
+				}
+			}
+
+			run, fired := evaluateHalt(t, rules, nil)
+
+			if !reflect.DeepEqual(fired, tt.fired) {
+				t.Errorf("Expected %v to fire, got %v", tt.fired, fired)
+			}
+			if run.HaltedBy() != tt.haltedBy {
+				t.Errorf("Expected the run to halt after %q, got %q", tt.haltedBy, run.HaltedBy())
+			}
+		})
+	}
+}
+
+func TestEventContext_Halt(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			rules := []*gre.Rule{
+				{
+					Name:       "vip-price",
+					Priority:   30,
+					Conditions: gre.All(gre.Equal("tier", "standard")),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "member-price",
+					Priority:   20,
+					Conditions: gre.All(gre.Equal("member", true)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "default-price",
+					Priority:   10,
+					Conditions: gre.All(gre.GreaterThan("amount", 0)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+			}
+
+			run, fired := evaluateHalt(t, rules, func(ctx gre.EventContext) {
+				if ctx.RuleName == "member-price" {
+					ctx.Halt()
+				}
+			}, opts...)
+
+			if !reflect.DeepEqual(fired, []string{"vip-price", "member-price"}) {
+				t.Errorf("Expected vip-price and member-price to fire, got %v", fired)
+			}
+			if run.HaltedBy() != "member-price" {
+				t.Errorf("Expected the run to halt after member-price, got %q", run.HaltedBy())
+			}
+			if res := run.Results()["default-price"]; res.Status != gre.RuleStatusNotEvaluated {
+				t.Errorf("Expected default-price not to be evaluated, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestEventContext_HaltOutsideRun(t *testing.T) {
+	engine := gre.NewEngine()
+	engine.RegisterEvent(gre.Event{
+		Name: "record",
+		Action: func(ctx gre.EventContext) error {
+			ctx.Halt()
+			return nil
+		},
+	})
+
+	if err := engine.HandleEvent("record", "manual", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestHalt_SmartSkipStatus(t *testing.T) {
+	rules := []*gre.Rule{
+		{
+			Name:       "vip-price",
+			Priority:   30,
+			Conditions: gre.All(gre.Equal("unknown", true)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "member-price",
+			Priority:   20,
+			Conditions: gre.All(gre.Equal("member", true)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "default-price",
+			Priority:   10,
+			Conditions: gre.All(gre.GreaterThan("amount", 0)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+	}
+
+	run, _ := evaluateHalt(t, rules, nil, gre.WithSmartSkip())
+
+	if res := run.Results()["vip-price"]; res.Status != gre.RuleStatusSkipped {
+		t.Errorf("Expected vip-price to be skipped, got %+v", res)
+	}
+}
+
+func TestRuleStopProcessing_Serialization(t *testing.T) {
+	rule := gre.NewRuleBuilder().
+		WithName("first").
+		WithStopProcessing(gre.StopOnSuccess).
+		WithConditions(gre.ConditionNode{Condition: gre.Equal("member", true)}).
+		Build()
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var decoded gre.Rule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.StopProcessing != gre.StopOnSuccess {
+		t.Errorf("Expected stopProcessing %q, got %q (%s)", gre.StopOnSuccess, decoded.StopProcessing, data)
+	}
+
+	rule.StopProcessing = "sometimes"
+	if diags := gre.ValidateRule(rule); !diags.HasErrors() || diags[0].Code != gre.DiagnosticInvalidStopProcessing {
+		t.Errorf("Expected an INVALID_STOP_PROCESSING diagnostic, got %v", diags)
+	}
+}
+
+func TestStopOnFirstMatch_AllPassPolicy(t *testing.T) {
+	// Rules not evaluated because the run halted do not decline an all-pass decision
+	engine := gre.NewEngine(gre.WithStopOnFirstMatch(), gre.WithHitPolicy(gre.AllPassPolicy()))
+	engine.AddRules(
+		&gre.Rule{Name: "member-price", Priority: 20, Conditions: gre.All(gre.Equal("member", true))},
+		&gre.Rule{Name: "vip-price", Priority: 10, Conditions: gre.All(gre.Equal("tier", "vip"))},
+	)
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("member", true)
+	almanac.AddFact("tier", "standard")
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status := run.Results()["vip-price"].Status; status != gre.RuleStatusNotEvaluated {
+		t.Fatalf("Expected vip-price not to be evaluated, got %q", status)
+	}
+
+	resp := run.GenerateResponse()
+	if resp.Decision != gre.DecisionAuthorize || !reflect.DeepEqual(resp.DecidingRules, []string{"member-price"}) {
+		t.Errorf("Expected member-price to authorize, got %s by %v", resp.Decision, resp.DecidingRules)
+	}
+}
@@ -118,6 +118,8 @@ func TestEngine_GenerateResponse_JSONComparison(t *testing.T) {
 	// 2. Le JSON que nous attendons (écrit à la main)
 	expectedJSON := `{
 		"decision": "authorize",
+		"policy": "any",
+		"decidingRules": ["simple-rule"],
 		"reason": "Rule 'simple-rule' determined the result",
 		"events": [
 			{
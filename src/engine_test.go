@@ -778,6 +778,13 @@ func TestWithPrioritySorting(t *testing.T) {
 			t.Fatal("Expected engine to be created")
 		}
 	})
+
+	t.Run("keeps stable sort rule values", func(t *testing.T) {
+		// Stored or compared sort rules must not change when option keys are added
+		if gre.SortDefault != 6 || gre.SortRuleASC != 7 || gre.SortRuleDESC != 8 {
+			t.Errorf("Expected sort rules 6, 7 and 8, got %d, %d and %d", gre.SortDefault, gre.SortRuleASC, gre.SortRuleDESC)
+		}
+	})
 }
 
 func TestWithoutPrioritySorting(t *testing.T) {
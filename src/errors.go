@@ -1,6 +1,9 @@
 package gorulesengine
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrorType identifies the category of error that occurred.
 type ErrorType string
@@ -22,6 +25,8 @@ const (
 	ErrEvent ErrorType = "EVENT_ERROR"
 	// ErrJSON indicates an error parsing or unmarshaling JSON.
 	ErrJSON ErrorType = "JSON_ERROR"
+	// ErrYAML indicates an error parsing or unmarshaling YAML.
+	ErrYAML ErrorType = "YAML_ERROR"
 	// ErrLoader indicates an error related to loading rules or data.
 	ErrLoader ErrorType = "LOADER_ERROR"
 )
@@ -79,6 +84,28 @@ type ConditionError struct {
 	Err       error     // Underlying error
 }
 
+// ValidationError represents a rule (or rule set) rejected because validation reported errors.
+type ValidationError struct {
+	Diagnostics Diagnostics // The error diagnostics that caused the rejection
+}
+
+// FileParseError represents a rule file that could not be parsed.
+// Line and Column are 1-based; they are zero when unknown (YAML errors may only report a line).
+type FileParseError struct {
+	File   string // Path of the offending file
+	Line   int    // Line of the error
+	Column int    // Column of the error
+	Offset int64  // Byte offset of the error
+	Err    error  // Underlying error
+}
+
+// AsyncEventError represents an error of an event executed asynchronously.
+type AsyncEventError struct {
+	EventName string // The event that failed
+	RuleName  string // The rule that triggered the event
+	Err       error  // Underlying error
+}
+
 // Error methods to convert to RuleEngineError
 func (e *AlmanacError) Error() string {
 	return (&RuleEngineError{
@@ -165,3 +192,51 @@ func (e *FactError) Error() string {
 func (e *FactError) Unwrap() error {
 	return e.Err
 }
+
+// Error methods to convert to RuleEngineError
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Diagnostics))
+	for i, diag := range e.Diagnostics {
+		msgs[i] = diag.String()
+	}
+	return (&RuleEngineError{
+		Type: ErrRule,
+		Msg: fmt.Sprintf(
+			"validation failed with %d error(s): %s",
+			len(e.Diagnostics),
+			strings.Join(msgs, "; "),
+		),
+	}).Error()
+}
+
+// Error methods to convert to RuleEngineError
+func (e *FileParseError) Error() string {
+	msg := fmt.Sprintf("file=%s", e.File)
+	if e.Line > 0 {
+		msg = fmt.Sprintf("file=%s line=%d column=%d offset=%d", e.File, e.Line, e.Column, e.Offset)
+	}
+	return (&RuleEngineError{
+		Type: ErrLoader,
+		Msg:  msg,
+		Err:  e.Err,
+	}).Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *FileParseError) Unwrap() error {
+	return e.Err
+}
+
+// Error methods to convert to RuleEngineError
+func (e *AsyncEventError) Error() string {
+	return (&RuleEngineError{
+		Type: ErrEvent,
+		Msg:  fmt.Sprintf("event=%s rule=%s", e.EventName, e.RuleName),
+		Err:  e.Err,
+	}).Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *AsyncEventError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,10 @@
 package gorulesengine
 
-import "time"
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
 
 // EventMode defines how an event should be executed
 type EventMode int
@@ -14,11 +18,43 @@ const (
 
 // EventContext provides context information about the rule execution that triggered an event
 type EventContext struct {
+	Context   context.Context        // Context of the run that triggered the event
 	RuleName  string                 // Name of the rule that triggered the event
 	Result    bool                   // Result of the rule evaluation (true=success, false=failure)
 	Almanac   *Almanac               // Reference to the almanac used for evaluation
 	Timestamp time.Time              // When the event was triggered
 	Params    map[string]interface{} // Additional parameters from the event
+
+	halt *runHalt // Halt signal of the run, if the event was fired by the engine
+}
+
+// Halt stops the run that fired the event: the rules after the current one are
+// not evaluated and reported with RuleStatusNotEvaluated. It has no effect on
+// events handled outside of a run or after the run completed.
+func (c EventContext) Halt() {
+	if c.halt != nil {
+		c.halt.requested.Store(true)
+	}
+}
+
+// runHalt is the halt signal shared by the events of a run, carried by its context.
+type runHalt struct {
+	requested atomic.Bool
+}
+
+// runHaltKey is the context key of the halt signal of a run.
+type runHaltKey struct{}
+
+// withRunHalt returns a context carrying a new halt signal.
+func withRunHalt(ctx context.Context) (context.Context, *runHalt) {
+	halt := &runHalt{}
+	return context.WithValue(ctx, runHaltKey{}, halt), halt
+}
+
+// runHaltFromContext returns the halt signal carried by ctx, or nil.
+func runHaltFromContext(ctx context.Context) *runHalt {
+	halt, _ := ctx.Value(runHaltKey{}).(*runHalt)
+	return halt
 }
 
 // Event represents an event triggered by a rule when its conditions are met.
@@ -28,6 +64,7 @@ type Event struct {
 	Params map[string]interface{}   // Optional parameters passed with the event
 	Action func(EventContext) error // Action to execute when the event is handled
 	Mode   EventMode                // Execution mode (sync or async)
+	Retry  *RetryPolicy             // Optional retry policy of the action
 }
 
 // EventHandler defines the interface for handling events triggered by rules.
@@ -0,0 +1,256 @@
+package gorulesengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// AsyncQueuePolicy defines what happens to an async event when the dispatcher queue is full.
+type AsyncQueuePolicy string
+
+const (
+	// AsyncQueueBlock waits for a free slot in the queue (default).
+	// The wait is aborted if the run context is done.
+	AsyncQueueBlock AsyncQueuePolicy = "block"
+	// AsyncQueueDrop drops the event and reports ErrAsyncQueueFull through OnAsyncError.
+	AsyncQueueDrop AsyncQueuePolicy = "drop"
+
+	// defaultAsyncQueueSize is the queue size of the dispatcher when none is configured
+	defaultAsyncQueueSize = 1024
+)
+
+// ErrAsyncQueueFull is reported through OnAsyncError for async events dropped
+// because the dispatcher queue was full (AsyncQueueDrop).
+var ErrAsyncQueueFull = errors.New("async event queue is full")
+
+// asyncDispatcher executes async events on a bounded pool of workers.
+type asyncDispatcher struct {
+	queue    chan func()
+	policy   AsyncQueuePolicy
+	workers  sync.WaitGroup
+	mu       sync.RWMutex  // Guards closed against the sends on queue
+	closed   bool          // Set once the queue is closed
+	stopping chan struct{} // Closed when the shutdown starts, to release blocked senders
+	stopOnce sync.Once
+}
+
+// WithAsyncDispatcher bounds the execution of async events: they are queued (up to
+// queueSize) and executed by the given number of workers. When the queue is full,
+// policy either blocks the run until a slot is free or drops the event.
+// Non-positive values select the defaults (GOMAXPROCS workers, 1024 events, AsyncQueueBlock).
+// The dispatcher is started on the first async event, with the options set at that time.
+func WithAsyncDispatcher(workers, queueSize int, policy AsyncQueuePolicy) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyAsyncWorkers] = workers
+		e.options[EngineOptionKeyAsyncQueueSize] = queueSize
+		e.options[EngineOptionKeyAsyncQueuePolicy] = policy
+	}
+}
+
+// newAsyncDispatcher starts a dispatcher configured by the engine options.
+func newAsyncDispatcher(options map[string]interface{}) *asyncDispatcher {
+	workers, _ := options[EngineOptionKeyAsyncWorkers].(int)
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	queueSize, _ := options[EngineOptionKeyAsyncQueueSize].(int)
+	if queueSize <= 0 {
+		queueSize = defaultAsyncQueueSize
+	}
+	policy, _ := options[EngineOptionKeyAsyncQueuePolicy].(AsyncQueuePolicy)
+	if policy != AsyncQueueDrop {
+		policy = AsyncQueueBlock
+	}
+
+	d := &asyncDispatcher{
+		queue:    make(chan func(), queueSize),
+		policy:   policy,
+		stopping: make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		d.workers.Add(1)
+		go func() {
+			defer d.workers.Done()
+			for task := range d.queue {
+				task()
+			}
+		}()
+	}
+	return d
+}
+
+// submit queues a task according to the queue policy. It returns ErrAsyncQueueFull
+// when the task is dropped, and an ErrEvent error once the dispatcher is shut down.
+func (d *asyncDispatcher) submit(ctx context.Context, task func()) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return newShutdownError()
+	}
+
+	if d.policy == AsyncQueueDrop {
+		select {
+		case d.queue <- task:
+			return nil
+		default:
+			return ErrAsyncQueueFull
+		}
+	}
+
+	select {
+	case d.queue <- task:
+		return nil
+	case <-d.stopping:
+		return newShutdownError()
+	case <-ctx.Done():
+		return newCanceledError(ctx.Err())
+	}
+}
+
+// shutdown stops accepting tasks and waits until the queued ones are executed,
+// or until ctx is done.
+func (d *asyncDispatcher) shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() {
+		close(d.stopping)
+		d.mu.Lock()
+		d.closed = true
+		close(d.queue)
+		d.mu.Unlock()
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return &RuleEngineError{
+			Type: ErrEngine,
+			Msg:  "async events were not drained before the shutdown deadline",
+			Err:  ctx.Err(),
+		}
+	}
+}
+
+// newShutdownError creates the error returned for async events fired after Shutdown.
+func newShutdownError() error {
+	return &RuleEngineError{
+		Type: ErrEvent,
+		Msg:  "engine is shut down: async events are no longer accepted",
+	}
+}
+
+// OnAsyncError sets a callback to be called with the errors of async events:
+// errors returned by their action or by the global handler, and events dropped
+// because the queue was full. It is called from the dispatcher workers.
+func (e *Engine) OnAsyncError(callback func(*AsyncEventError)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onAsyncError = callback
+}
+
+// Shutdown stops accepting async events and waits until the pending ones are
+// executed. If ctx is done first, it returns an ErrEngine error and the remaining
+// events keep executing in the background. Async events fired after Shutdown
+// are rejected with an ErrEvent error; sync events are not affected.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	return e.asyncDispatcher().shutdown(ctx)
+}
+
+// asyncDispatcher returns the dispatcher of the engine, starting it if needed.
+func (e *Engine) asyncDispatcher() *asyncDispatcher {
+	e.mu.RLock()
+	d := e.dispatcher
+	e.mu.RUnlock()
+	if d != nil {
+		return d
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.dispatcher == nil {
+		e.dispatcher = newAsyncDispatcher(e.options)
+	}
+	return e.dispatcher
+}
+
+// reportAsyncError passes an async event error to the OnAsyncError callback, if any.
+func (e *Engine) reportAsyncError(eventName, ruleName string, err error) {
+	e.mu.RLock()
+	callback := e.onAsyncError
+	e.mu.RUnlock()
+
+	if callback != nil {
+		callback(&AsyncEventError{EventName: eventName, RuleName: ruleName, Err: err})
+	}
+}
+
+// dispatchAsyncEvent queues the execution of an async event, wrapped by the
+// middleware chain. Errors of the action (after its retries), of the global
+// handler and of the middleware are reported through OnAsyncError.
+// The run context only bounds the queue admission: the task gets a context that
+// keeps its values but is not cancelled when the run ends, so retries still happen.
+func (e *Engine) dispatchAsyncEvent(event Event, ctx EventContext, handler EventHandler, metrics MetricsCollector, sink DeadLetterSink, middleware []EventMiddleware) error {
+	runCtx := ctx.Context
+	ctx.Context = context.WithoutCancel(runCtx)
+
+	task := func() {
+		start := time.Now()
+		execution := EventExecution{EventName: event.Name, RuleName: ctx.RuleName}
+
+		var handleErr error
+		handle := func(event Event, ctx EventContext) error {
+			var errs []error
+
+			// Execute action if defined, with retries
+			if err := e.runEventAction(event, ctx, sink, &execution); err != nil {
+				errs = append(errs, err)
+				e.reportAsyncError(event.Name, ctx.RuleName, fmt.Errorf("error executing action: %w", err))
+			}
+			// Execute global handler if defined
+			if handler != nil {
+				if err := handler.Handle(event, ctx); err != nil {
+					errs = append(errs, err)
+					e.reportAsyncError(event.Name, ctx.RuleName, fmt.Errorf("error in global handler: %w", err))
+				}
+			}
+
+			handleErr = errors.Join(errs...)
+			return handleErr
+		}
+
+		err := chainEventMiddleware(middleware, handle)(event, ctx)
+		// Errors of the action and handler are already reported
+		if err != nil && (handleErr == nil || !errors.Is(err, handleErr)) {
+			e.reportAsyncError(event.Name, ctx.RuleName, err)
+		}
+
+		execution.Duration = time.Since(start)
+		if err != nil {
+			execution.Error = err.Error()
+		}
+		observeEventExecution(metrics, execution, ctx.Result)
+	}
+
+	err := e.asyncDispatcher().submit(runCtx, task)
+	if errors.Is(err, ErrAsyncQueueFull) {
+		e.reportAsyncError(event.Name, ctx.RuleName, err)
+		return nil
+	}
+	return err
+}
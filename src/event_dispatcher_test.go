@@ -0,0 +1,222 @@
+package gorulesengine_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// asyncErrors collects the errors reported through OnAsyncError.
+type asyncErrors struct {
+	mu   sync.Mutex
+	errs []*gre.AsyncEventError
+}
+
+func (a *asyncErrors) add(err *gre.AsyncEventError) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.errs = append(a.errs, err)
+}
+
+func (a *asyncErrors) list() []*gre.AsyncEventError {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*gre.AsyncEventError(nil), a.errs...)
+}
+
+func TestAsyncDispatcher_BoundedWorkers(t *testing.T) {
+	engine := gre.NewEngine(gre.WithAsyncDispatcher(2, 100, gre.AsyncQueueBlock))
+
+	var running, maxRunning, executed int32
+	engine.RegisterEvent(gre.Event{
+		Name: "async",
+		Mode: gre.EventModeAsync,
+		Action: func(ctx gre.EventContext) error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&executed, 1)
+			return nil
+		},
+	})
+
+	for i := 0; i < 20; i++ {
+		if err := engine.HandleEvent("async", "rule", true, gre.NewAlmanac(), nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if executed != 20 {
+		t.Errorf("Expected 20 events to be drained, got %d", executed)
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent events, got %d", maxRunning)
+	}
+}
+
+func TestAsyncDispatcher_DropPolicy(t *testing.T) {
+	engine := gre.NewEngine(gre.WithAsyncDispatcher(1, 1, gre.AsyncQueueDrop))
+
+	var reported asyncErrors
+	engine.OnAsyncError(reported.add)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	engine.RegisterEvent(gre.Event{
+		Name: "async",
+		Mode: gre.EventModeAsync,
+		Action: func(ctx gre.EventContext) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-release
+			return nil
+		},
+	})
+
+	// The first event occupies the worker, the second fills the queue
+	_ = engine.HandleEvent("async", "first", true, gre.NewAlmanac(), nil)
+	<-started
+	_ = engine.HandleEvent("async", "second", true, gre.NewAlmanac(), nil)
+
+	if err := engine.HandleEvent("async", "third", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected dropped events not to fail, got %v", err)
+	}
+
+	close(release)
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	errs := reported.list()
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 dropped event, got %v", errs)
+	}
+	if errs[0].EventName != "async" || errs[0].RuleName != "third" || !errors.Is(errs[0], gre.ErrAsyncQueueFull) {
+		t.Errorf("Expected the third event to be dropped, got %v", errs[0])
+	}
+}
+
+func TestAsyncDispatcher_BlockPolicyCanceled(t *testing.T) {
+	engine := gre.NewEngine(gre.WithAsyncDispatcher(1, 1, gre.AsyncQueueBlock))
+
+	release := make(chan struct{})
+	engine.RegisterEvent(gre.Event{
+		Name: "async",
+		Mode: gre.EventModeAsync,
+		Action: func(ctx gre.EventContext) error {
+			<-release
+			return nil
+		},
+	})
+	defer close(release)
+
+	_ = engine.HandleEvent("async", "first", true, gre.NewAlmanac(), nil)
+	_ = engine.HandleEvent("async", "second", true, gre.NewAlmanac(), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// The worker holds one event and the queue another: the third one blocks
+	err := engine.HandleEventContext(ctx, "async", "third", true, gre.NewAlmanac(), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the blocked event to be canceled, got %v", err)
+	}
+}
+
+func TestAsyncDispatcher_ErrorReporting(t *testing.T) {
+	engine := gre.NewEngine()
+
+	var reported asyncErrors
+	engine.OnAsyncError(reported.add)
+
+	actionErr := errors.New("action failed")
+	engine.RegisterEvent(gre.Event{
+		Name:   "async",
+		Mode:   gre.EventModeAsync,
+		Action: func(ctx gre.EventContext) error { return actionErr },
+	})
+	engine.SetEventHandler(&MockEventHandler{ShouldError: true, ErrorMessage: "handler failed"})
+
+	if err := engine.HandleEvent("async", "rule", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	errs := reported.list()
+	if len(errs) != 2 {
+		t.Fatalf("Expected the action and handler errors, got %v", errs)
+	}
+	if !errors.Is(errs[0], actionErr) || errs[0].EventName != "async" || errs[0].RuleName != "rule" {
+		t.Errorf("Expected the action error, got %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "handler failed") {
+		t.Errorf("Expected the handler error, got %v", errs[1])
+	}
+}
+
+func TestEngine_Shutdown(t *testing.T) {
+	t.Run("rejects async events after shutdown", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.RegisterEvents(
+			gre.Event{Name: "async", Mode: gre.EventModeAsync},
+			gre.Event{Name: "sync"},
+		)
+
+		if err := engine.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := engine.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected Shutdown to be idempotent, got %v", err)
+		}
+
+		var engineErr *gre.RuleEngineError
+		err := engine.HandleEvent("async", "rule", true, gre.NewAlmanac(), nil)
+		if !errors.As(err, &engineErr) || engineErr.Type != gre.ErrEvent {
+			t.Errorf("Expected an ErrEvent error, got %v", err)
+		}
+		if err := engine.HandleEvent("sync", "rule", true, gre.NewAlmanac(), nil); err != nil {
+			t.Errorf("Expected sync events to keep working, got %v", err)
+		}
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		engine := gre.NewEngine()
+		release := make(chan struct{})
+		defer close(release)
+		engine.RegisterEvent(gre.Event{
+			Name: "async",
+			Mode: gre.EventModeAsync,
+			Action: func(ctx gre.EventContext) error {
+				<-release
+				return nil
+			},
+		})
+		_ = engine.HandleEvent("async", "rule", true, gre.NewAlmanac(), nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := engine.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected a deadline error, got %v", err)
+		}
+	})
+}
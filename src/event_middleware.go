@@ -0,0 +1,42 @@
+package gorulesengine
+
+// EventHandlerFunc is a function handling an event. It implements EventHandler.
+type EventHandlerFunc func(event Event, ctx EventContext) error
+
+// Handle calls f(event, ctx).
+func (f EventHandlerFunc) Handle(event Event, ctx EventContext) error {
+	return f(event, ctx)
+}
+
+// EventMiddleware wraps the handling of an event. It can act before and after
+// calling next, change the event or its context (e.g. redact params), or skip
+// next and return an error (e.g. an authorization check).
+type EventMiddleware func(next EventHandlerFunc) EventHandlerFunc
+
+// UseEventMiddleware appends middleware to the chain wrapping every registered
+// event, sync or async. The chain wraps the action (with its retries) and the
+// global handler, once per event. The first middleware added is the outermost:
+// it runs first before next, and last after it.
+// The built-in setFact event is not wrapped.
+func (e *Engine) UseEventMiddleware(middleware ...EventMiddleware) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Copy so that events being handled keep the chain they started with
+	chain := make([]EventMiddleware, 0, len(e.eventMiddleware)+len(middleware))
+	chain = append(chain, e.eventMiddleware...)
+	for _, mw := range middleware {
+		if mw != nil {
+			chain = append(chain, mw)
+		}
+	}
+	e.eventMiddleware = chain
+}
+
+// chainEventMiddleware wraps handler with the middleware, the first one being the outermost.
+func chainEventMiddleware(middleware []EventMiddleware, handler EventHandlerFunc) EventHandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,170 @@
+package gorulesengine_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// traceMiddleware records when it is entered and left.
+func traceMiddleware(name string, mu *sync.Mutex, trace *[]string) gre.EventMiddleware {
+	return func(next gre.EventHandlerFunc) gre.EventHandlerFunc {
+		return func(event gre.Event, ctx gre.EventContext) error {
+			mu.Lock()
+			*trace = append(*trace, name+":before")
+			mu.Unlock()
+
+			err := next(event, ctx)
+
+			mu.Lock()
+			*trace = append(*trace, name+":after")
+			mu.Unlock()
+			return err
+		}
+	}
+}
+
+func TestEventMiddleware_Order(t *testing.T) {
+	modes := map[string]gre.EventMode{
+		"sync":  gre.EventModeSync,
+		"async": gre.EventModeAsync,
+	}
+
+	for name, mode := range modes {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var trace []string
+			record := func(step string) {
+				mu.Lock()
+				trace = append(trace, step)
+				mu.Unlock()
+			}
+
+			engine := gre.NewEngine()
+			engine.UseEventMiddleware(traceMiddleware("outer", &mu, &trace))
+			engine.UseEventMiddleware(traceMiddleware("inner", &mu, &trace), nil)
+			engine.SetEventHandler(gre.EventHandlerFunc(func(event gre.Event, ctx gre.EventContext) error {
+				record("handler")
+				return nil
+			}))
+			engine.RegisterEvent(gre.Event{
+				Name: "notify",
+				Mode: mode,
+				Action: func(ctx gre.EventContext) error {
+					record("action")
+					return nil
+				},
+			})
+
+			if err := engine.HandleEvent("notify", "rule", true, gre.NewAlmanac(), nil); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if err := engine.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			expected := []string{"outer:before", "inner:before", "action", "handler", "inner:after", "outer:after"}
+			if !reflect.DeepEqual(trace, expected) {
+				t.Errorf("Expected %v, got %v", expected, trace)
+			}
+		})
+	}
+}
+
+func TestEventMiddleware_RedactsParams(t *testing.T) {
+	engine := gre.NewEngine()
+	engine.UseEventMiddleware(func(next gre.EventHandlerFunc) gre.EventHandlerFunc {
+		return func(event gre.Event, ctx gre.EventContext) error {
+			params := make(map[string]interface{}, len(ctx.Params))
+			for k, v := range ctx.Params {
+				params[k] = v
+			}
+			params["card"] = "****"
+			ctx.Params = params
+			return next(event, ctx)
+		}
+	})
+
+	var seen interface{}
+	engine.RegisterEvent(gre.Event{
+		Name:   "charge",
+		Params: map[string]interface{}{"card": "4111111111111111"},
+		Action: func(ctx gre.EventContext) error {
+			seen = ctx.Params["card"]
+			return nil
+		},
+	})
+
+	if err := engine.HandleEvent("charge", "rule", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if seen != "****" {
+		t.Errorf("Expected the action to see the redacted card, got %v", seen)
+	}
+}
+
+func TestEventMiddleware_ShortCircuit(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	deny := func(next gre.EventHandlerFunc) gre.EventHandlerFunc {
+		return func(event gre.Event, ctx gre.EventContext) error {
+			if ctx.RuleName != "trusted" {
+				return errForbidden
+			}
+			return next(event, ctx)
+		}
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.UseEventMiddleware(deny)
+
+		calls := 0
+		engine.RegisterEvent(gre.Event{
+			Name:   "notify",
+			Action: func(ctx gre.EventContext) error { calls++; return nil },
+		})
+
+		if err := engine.HandleEvent("notify", "untrusted", true, gre.NewAlmanac(), nil); !errors.Is(err, errForbidden) {
+			t.Errorf("Expected the middleware error, got %v", err)
+		}
+		if err := engine.HandleEvent("notify", "trusted", true, gre.NewAlmanac(), nil); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected the action to run once, got %d", calls)
+		}
+	})
+
+	t.Run("async", func(t *testing.T) {
+		engine := gre.NewEngine(gre.WithAsyncDispatcher(1, 0, gre.AsyncQueueBlock))
+		engine.UseEventMiddleware(deny)
+
+		var reported asyncErrors
+		engine.OnAsyncError(reported.add)
+
+		actionErr := errors.New("action failed")
+		engine.RegisterEvent(gre.Event{
+			Name:   "notify",
+			Mode:   gre.EventModeAsync,
+			Action: func(ctx gre.EventContext) error { return actionErr },
+		})
+
+		_ = engine.HandleEvent("notify", "untrusted", true, gre.NewAlmanac(), nil)
+		_ = engine.HandleEvent("notify", "trusted", true, gre.NewAlmanac(), nil)
+		if err := engine.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		errs := reported.list()
+		if len(errs) != 2 {
+			t.Fatalf("Expected 2 reported errors, got %v", errs)
+		}
+		if !errors.Is(errs[0], errForbidden) || !errors.Is(errs[1], actionErr) {
+			t.Errorf("Expected the middleware then the action error once each, got %v", errs)
+		}
+	})
+}
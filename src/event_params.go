@@ -0,0 +1,163 @@
+package gorulesengine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+)
+
+// WithEventParamResolution resolves the fact references and templates held by
+// event params when the event fires and in GenerateResponse (see
+// resolveEventParams). Without it, event params are passed as is, so rules can
+// carry {{ }} text meant for downstream templates.
+func WithEventParamResolution() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyEventParamResolution] = true
+	}
+}
+
+// WithoutEventParamResolution passes event params as is (default).
+func WithoutEventParamResolution() EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyEventParamResolution] = false
+	}
+}
+
+// paramTemplates caches the parsed templates of event params by source.
+var paramTemplates sync.Map
+
+// resolveEventParams returns a copy of params where fact references and templates
+// are replaced by their values from the almanac, at any depth:
+//
+//   - an object {"fact": "user", "path": "$.id"} (or a FactReference) is replaced by the fact value;
+//   - a string holding {{ fact "transaction" "$.amount" }} is rendered as a text/template,
+//     where fact takes a fact ID and an optional JSONPath. A string made of a single
+//     fact call is replaced by the raw fact value (e.g. a number) instead of its text.
+func resolveEventParams(ctx context.Context, almanac *Almanac, params map[string]interface{}) (map[string]interface{}, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	resolved := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		v, err := resolveParamValue(ctx, almanac, value)
+		if err != nil {
+			return nil, fmt.Errorf("param '%s': %w", key, err)
+		}
+		resolved[key] = v
+	}
+	return resolved, nil
+}
+
+// resolveParamValue resolves the fact references and templates held by a param value.
+func resolveParamValue(ctx context.Context, almanac *Almanac, value interface{}) (interface{}, error) {
+	if ref, ok := asFactReference(value); ok {
+		return resolveParamFact(ctx, almanac, ref.Fact, ref.Params, ref.Path)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return resolveEventParams(ctx, almanac, v)
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			r, err := resolveParamValue(ctx, almanac, item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		return renderParamTemplate(ctx, almanac, v)
+	}
+	return value, nil
+}
+
+// resolveParamFact returns the value of a fact referenced by an event param.
+func resolveParamFact(ctx context.Context, almanac *Almanac, id FactID, params map[string]interface{}, path string) (interface{}, error) {
+	if almanac == nil {
+		return nil, fmt.Errorf("cannot resolve fact '%s' without an almanac", id)
+	}
+	return almanac.GetFactValueContext(ctx, id, params, path)
+}
+
+// renderParamTemplate renders a templated param against the almanac.
+func renderParamTemplate(ctx context.Context, almanac *Almanac, source string) (interface{}, error) {
+	tmpl, err := parseParamTemplate(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var last interface{}
+	tmpl, err = tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Funcs(template.FuncMap{
+		"fact": func(id string, path ...string) (interface{}, error) {
+			if len(path) > 1 {
+				return nil, fmt.Errorf("fact takes a fact ID and an optional path, got %d arguments", len(path)+1)
+			}
+			value, err := resolveParamFact(ctx, almanac, FactID(id), nil, strings.Join(path, ""))
+			last = value
+			return value, err
+		},
+	})
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, nil); err != nil {
+		return nil, err
+	}
+	if isSingleFactCall(tmpl) {
+		return last, nil
+	}
+	return out.String(), nil
+}
+
+// parseParamTemplate parses a templated param, caching the result.
+func parseParamTemplate(source string) (*template.Template, error) {
+	if cached, ok := paramTemplates.Load(source); ok {
+		return cached.(*template.Template), nil
+	}
+
+	tmpl, err := template.New("param").
+		Funcs(template.FuncMap{"fact": func(string, ...string) (interface{}, error) { return nil, nil }}).
+		Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	paramTemplates.Store(source, tmpl)
+	return tmpl, nil
+}
+
+// isSingleFactCall reports whether a template is a single {{ fact ... }} call.
+func isSingleFactCall(tmpl *template.Template) bool {
+	if tmpl.Tree == nil || len(tmpl.Tree.Root.Nodes) != 1 {
+		return false
+	}
+	action, ok := tmpl.Tree.Root.Nodes[0].(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 {
+		return false
+	}
+	ident, ok := action.Pipe.Cmds[0].Args[0].(*parse.IdentifierNode)
+	return ok && ident.Ident == "fact"
+}
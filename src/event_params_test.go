@@ -0,0 +1,240 @@
+package gorulesengine_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+func TestEventParams_Resolution(t *testing.T) {
+	engine := gre.NewEngine(gre.WithEventParamResolution())
+
+	var received map[string]interface{}
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify-risk-team",
+		Params: map[string]interface{}{"team": "risk", "user": map[string]interface{}{"fact": "user", "path": "$.id"}},
+		Action: func(ctx gre.EventContext) error {
+			received = ctx.Params
+			return nil
+		},
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess: []gre.RuleEvent{{Name: "notify-risk-team", Params: map[string]interface{}{
+			"amount":  `{{ fact "transaction" "$.amount" }}`,
+			"message": `{{ fact "user" "$.name" }} spent {{ fact "transaction" "$.amount" }} {{ fact "transaction" "$.currency" }}`,
+			"tags":    []interface{}{"fraud", `{{ fact "transaction" "$.currency" }}`},
+		}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("user", map[string]interface{}{"id": "u-42", "name": "Ada"})
+
+	if _, err := engine.Evaluate(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"team":    "risk",
+		"user":    "u-42",
+		"amount":  1250.5,
+		"message": "Ada spent 1250.5 EUR",
+		"tags":    []interface{}{"fraud", "EUR"},
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected %v, got %v", expected, received)
+	}
+}
+
+func TestEventParams_PassedAsIsByDefault(t *testing.T) {
+	// Without event param resolution, {{ }} text and fact-like objects are left for downstream use
+	params := map[string]interface{}{
+		"greeting": "Hello {{name}}",
+		"body":     `{{ fact "user" "$.name" }}`,
+		"target":   map[string]interface{}{"fact": "user", "path": "$.id"},
+	}
+	engine := gre.NewEngine()
+
+	var received map[string]interface{}
+	engine.RegisterEvent(gre.Event{
+		Name: "notify-risk-team",
+		Action: func(ctx gre.EventContext) error {
+			received = ctx.Params
+			return nil
+		},
+	})
+	rule := &gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess:  []gre.RuleEvent{{Name: "notify-risk-team", Params: params}},
+	}
+	engine.AddRule(rule)
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("user", map[string]interface{}{"id": "u-42", "name": "Ada"})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(received, params) {
+		t.Errorf("Expected the params as is, got %v", received)
+	}
+	if events := run.GenerateResponse().Events; len(events) != 1 || !reflect.DeepEqual(events[0].Params, params) || events[0].Error != "" {
+		t.Errorf("Expected the response params as is, got %+v", events)
+	}
+	if diags := gre.ValidateRule(rule); diags.HasErrors() {
+		t.Errorf("Expected no validation error, got %v", diags)
+	}
+}
+
+func TestEventParams_ResolutionError(t *testing.T) {
+	engine := gre.NewEngine(gre.WithEventParamResolution())
+	called := false
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify-risk-team",
+		Action: func(ctx gre.EventContext) error { called = true; return nil },
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess:  []gre.RuleEvent{{Name: "notify-risk-team", Params: map[string]interface{}{"score": map[string]interface{}{"fact": "score"}}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("score", func(params map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("scoring service unavailable")
+	})
+
+	_, err := engine.Evaluate(almanac)
+
+	var engineErr *gre.RuleEngineError
+	found := false
+	for e := err; errors.As(e, &engineErr); e = engineErr.Err {
+		if engineErr.Type == gre.ErrEvent {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Expected an ErrEvent error, got %v", err)
+	}
+	if called {
+		t.Error("Expected the action not to be called")
+	}
+}
+
+func TestEventParams_GenerateResponse(t *testing.T) {
+	engine := gre.NewEngine(gre.WithEventParamResolution())
+	engine.AddRule(&gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess: []gre.RuleEvent{{Name: "notify-risk-team", Params: map[string]interface{}{
+			"amount": `{{ fact "transaction" "$.amount" }}`,
+			"user":   map[string]interface{}{"fact": "user", "path": "$.id"},
+		}}},
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "broken",
+		Conditions: gre.All(gre.Equal("user", "nobody")),
+		OnFailure:  []gre.RuleEvent{{Name: "log", Params: map[string]interface{}{"score": `{{ fact "score" }}`}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("user", map[string]interface{}{"id": "u-42", "name": "Ada"})
+	almanac.AddFact("score", func(params map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("scoring service unavailable")
+	})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events := map[string]gre.EventResponse{}
+	for _, ev := range run.GenerateResponse().Events {
+		events[ev.Type] = ev
+	}
+
+	expected := map[string]interface{}{"amount": 1250.5, "user": "u-42"}
+	if ev := events["notify-risk-team"]; !reflect.DeepEqual(ev.Params, expected) || ev.Error != "" {
+		t.Errorf("Expected resolved params %v, got %+v", expected, ev)
+	}
+	if ev := events["log"]; !strings.Contains(ev.Error, string(gre.ErrEvent)) || ev.Params["score"] != `{{ fact "score" }}` {
+		t.Errorf("Expected the unresolved params and an ErrEvent error, got %+v", ev)
+	}
+}
+
+func TestEventParams_EngineGenerateResponse(t *testing.T) {
+	// The legacy Run API resolves params the same way as Evaluate
+	engine := gre.NewEngine(gre.WithEventParamResolution())
+	engine.AddRule(&gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess: []gre.RuleEvent{{Name: "notify-risk-team", Params: map[string]interface{}{
+			"amount": `{{ fact "transaction" "$.amount" }}`,
+			"user":   map[string]interface{}{"fact": "user", "path": "$.id"},
+		}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("user", map[string]interface{}{"id": "u-42", "name": "Ada"})
+
+	if _, err := engine.Run(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	response := engine.GenerateResponse()
+	if len(response.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %+v", response.Events)
+	}
+	expected := map[string]interface{}{"amount": 1250.5, "user": "u-42"}
+	if ev := response.Events[0]; !reflect.DeepEqual(ev.Params, expected) || ev.Error != "" {
+		t.Errorf("Expected resolved params %v, got %+v", expected, ev)
+	}
+}
+
+func TestEventParams_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name:       "high-risk",
+		Conditions: gre.All(&gre.Condition{Fact: "transaction", Path: "$.amount", Operator: gre.OperatorGreaterThan, Value: 1000}),
+		OnSuccess:  []gre.RuleEvent{{Name: "notify-risk-team", Params: map[string]interface{}{"amount": `{{ fact "transaction" `}}},
+	}
+
+	diags := gre.ValidateRule(rule)
+	if len(diags) != 1 || diags[0].Code != gre.DiagnosticInvalidEventParams || diags[0].Path != "$.onSuccess[0].params.amount" || diags[0].Severity != gre.SeverityWarning {
+		t.Errorf("Expected an INVALID_EVENT_PARAMS warning on the amount param, got %v", diags)
+	}
+}
+
+func TestEventParams_SetFactTemplate(t *testing.T) {
+	engine := gre.NewEngine(gre.WithEventParamResolution())
+	engine.AddRule(&gre.Rule{
+		Name:       "flag",
+		Conditions: gre.All(gre.Equal("user", "nobody")),
+		OnFailure:  []gre.RuleEvent{setFact("flaggedAmount", `{{ fact "transaction" "$.amount" }}`)},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("transaction", map[string]interface{}{"amount": 1250.5, "currency": "EUR"})
+	almanac.AddFact("user", map[string]interface{}{"id": "u-42", "name": "Ada"})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	value, err := run.Almanac().GetFactValue("flaggedAmount", nil, "")
+	if err != nil || value != 1250.5 {
+		t.Errorf("Expected the asserted fact to be 1250.5, got %v (%v)", value, err)
+	}
+}
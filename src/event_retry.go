@@ -0,0 +1,156 @@
+package gorulesengine
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RetryPolicy defines how the action of an event is retried when it fails.
+// The zero value does not retry.
+type RetryPolicy struct {
+	MaxAttempts    int              // Total number of attempts, including the first one
+	InitialBackoff time.Duration    // Delay before the first retry (no delay if zero)
+	MaxBackoff     time.Duration    // Upper bound of the delay between attempts (unbounded if zero)
+	Multiplier     float64          // Growth factor of the delay after each retry (2 if not positive)
+	Jitter         float64          // Fraction of the delay randomly removed, between 0 and 1
+	Retryable      func(error) bool // Reports whether an error is transient (all errors if nil)
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// retryable reports whether the action should be attempted again after err.
+func (p *RetryPolicy) retryable(err error, attempts int) bool {
+	if p == nil || attempts >= p.MaxAttempts {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// EventExecution describes the final outcome of an event handled by the engine.
+type EventExecution struct {
+	EventName    string        `json:"event"`
+	RuleName     string        `json:"rule"`
+	Attempts     int           `json:"attempts"`               // Attempts of the action (0 without action)
+	Error        string        `json:"error,omitempty"`        // Error of the last attempt or of the global handler
+	DeadLettered bool          `json:"deadLettered,omitempty"` // Set when the event was sent to the dead-letter sink
+	Duration     time.Duration `json:"duration"`               // Duration of all the attempts and the global handler
+}
+
+// runEventAction executes the action of an event, retrying it according to its
+// RetryPolicy while the event context is not done. When the action still fails,
+// the event is sent to the dead-letter sink, if any.
+func (e *Engine) runEventAction(event Event, ctx EventContext, sink DeadLetterSink, execution *EventExecution) error {
+	if event.Action == nil {
+		return nil
+	}
+
+	for {
+		execution.Attempts++
+		err := event.Action(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if !event.Retry.retryable(err, execution.Attempts) || !waitBackoff(ctx.Context, event.Retry.backoff(execution.Attempts)) {
+			if sink == nil {
+				return err
+			}
+			sinkErr := sink.Write(DeadLetter{
+				EventName: event.Name,
+				RuleName:  ctx.RuleName,
+				Result:    ctx.Result,
+				Params:    ctx.Params,
+				Attempts:  execution.Attempts,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			})
+			if sinkErr != nil {
+				return errors.Join(err, &RuleEngineError{
+					Type: ErrEvent,
+					Msg:  "cannot write the event to the dead-letter sink",
+					Err:  sinkErr,
+				})
+			}
+			execution.DeadLettered = true
+			return err
+		}
+	}
+}
+
+// waitBackoff waits for delay, returning false if ctx is done first.
+func waitBackoff(ctx context.Context, delay time.Duration) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// observeEventExecution reports the final outcome of an event to the metrics collector.
+func observeEventExecution(metrics MetricsCollector, execution EventExecution, result bool) {
+	if metrics == nil {
+		return
+	}
+	metrics.ObserveEventExecution(execution.EventName, execution.RuleName, result, execution.Duration)
+	if collector, ok := metrics.(EventExecutionCollector); ok {
+		collector.ObserveEventAttempts(execution)
+	}
+}
+
+// eventLog collects the executions of the sync events fired during a run, carried by its context.
+type eventLog struct {
+	mu         sync.Mutex
+	executions []EventExecution
+}
+
+// eventLogKey is the context key of the event log of a run.
+type eventLogKey struct{}
+
+// withEventLog returns a context carrying a new event log.
+func withEventLog(ctx context.Context) (context.Context, *eventLog) {
+	log := &eventLog{}
+	return context.WithValue(ctx, eventLogKey{}, log), log
+}
+
+// recordEventExecution appends execution to the event log carried by ctx, if any.
+func recordEventExecution(ctx context.Context, execution EventExecution) {
+	if log, ok := ctx.Value(eventLogKey{}).(*eventLog); ok {
+		log.mu.Lock()
+		log.executions = append(log.executions, execution)
+		log.mu.Unlock()
+	}
+}
@@ -0,0 +1,285 @@
+package gorulesengine_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+var errTransient = errors.New("service unavailable")
+
+// flakyAction fails with errTransient until it has been called failures times.
+func flakyAction(failures int) (func(gre.EventContext) error, *int) {
+	calls := 0
+	return func(ctx gre.EventContext) error {
+		calls++
+		if calls <= failures {
+			return errTransient
+		}
+		return nil
+	}, &calls
+}
+
+// attemptsCollector records the event executions reported to an EventExecutionCollector.
+type attemptsCollector struct {
+	*MockMetricsCollector
+	mu         sync.Mutex
+	executions []gre.EventExecution
+}
+
+func (c *attemptsCollector) ObserveEventAttempts(execution gre.EventExecution) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.executions = append(c.executions, execution)
+}
+
+func TestEventRetry_RecoversFromTransientErrors(t *testing.T) {
+	metrics := &attemptsCollector{MockMetricsCollector: NewMockMetricsCollector()}
+	sink := gre.NewMemoryDeadLetterSink()
+	engine := gre.NewEngine(gre.WithMetrics(metrics), gre.WithDeadLetterSink(sink))
+
+	action, calls := flakyAction(2)
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify",
+		Action: action,
+		Retry:  &gre.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Jitter: 0.5},
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "notify-customer",
+		Conditions: gre.All(gre.Equal("eligible", true)),
+		OnSuccess:  []gre.RuleEvent{{Name: "notify", Params: map[string]interface{}{"channel": "sms"}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("eligible", true)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+	if len(sink.Letters()) != 0 {
+		t.Errorf("Expected no dead letter, got %v", sink.Letters())
+	}
+
+	executions := run.EventExecutions()
+	if len(executions) != 1 || executions[0].Attempts != 3 || executions[0].Error != "" || executions[0].RuleName != "notify-customer" {
+		t.Errorf("Expected 1 successful execution after 3 attempts, got %+v", executions)
+	}
+	if len(metrics.executions) != 1 || metrics.executions[0].Attempts != 3 || metrics.EventExecCount != 1 {
+		t.Errorf("Expected the attempts to be observed once, got %+v", metrics.executions)
+	}
+}
+
+func TestEventRetry_DeadLetter(t *testing.T) {
+	sink := gre.NewMemoryDeadLetterSink()
+	engine := gre.NewEngine(gre.WithDeadLetterSink(sink))
+
+	action, calls := flakyAction(10)
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify",
+		Action: action,
+		Retry:  &gre.RetryPolicy{MaxAttempts: 3},
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "notify-customer",
+		Conditions: gre.All(gre.Equal("eligible", true)),
+		OnSuccess:  []gre.RuleEvent{{Name: "notify", Params: map[string]interface{}{"channel": "sms"}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("eligible", true)
+
+	run, err := engine.Evaluate(almanac)
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("Expected the action error, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+
+	letters := sink.Letters()
+	if len(letters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %v", letters)
+	}
+	letter := letters[0]
+	if letter.EventName != "notify" || letter.RuleName != "notify-customer" || letter.Attempts != 3 ||
+		letter.Error != errTransient.Error() || letter.Params["channel"] != "sms" || !letter.Result {
+		t.Errorf("Unexpected dead letter %+v", letter)
+	}
+
+	executions := run.EventExecutions()
+	if len(executions) != 1 || !executions[0].DeadLettered || executions[0].Error == "" {
+		t.Errorf("Expected a dead-lettered execution, got %+v", executions)
+	}
+}
+
+func TestEventRetry_NonRetryableError(t *testing.T) {
+	engine := gre.NewEngine()
+
+	fatal := errors.New("invalid recipient")
+	calls := 0
+	engine.RegisterEvent(gre.Event{
+		Name: "notify",
+		Action: func(ctx gre.EventContext) error {
+			calls++
+			return fatal
+		},
+		Retry: &gre.RetryPolicy{
+			MaxAttempts: 5,
+			Retryable:   func(err error) bool { return errors.Is(err, errTransient) },
+		},
+	})
+
+	err := engine.HandleEvent("notify", "rule", true, gre.NewAlmanac(), nil)
+	if !errors.Is(err, fatal) {
+		t.Fatalf("Expected the action error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+}
+
+func TestEventRetry_BackoffCanceled(t *testing.T) {
+	engine := gre.NewEngine()
+
+	action, calls := flakyAction(10)
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify",
+		Action: action,
+		Retry:  &gre.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := engine.HandleEventContext(ctx, "notify", "rule", true, gre.NewAlmanac(), nil)
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("Expected the action error, got %v", err)
+	}
+	if *calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("Expected the backoff to stop with the context, got %d attempts in %v", *calls, time.Since(start))
+	}
+}
+
+func TestEventRetry_AsyncDeadLetter(t *testing.T) {
+	sink := gre.NewMemoryDeadLetterSink()
+	engine := gre.NewEngine(gre.WithDeadLetterSink(sink))
+
+	var reported asyncErrors
+	engine.OnAsyncError(reported.add)
+
+	action, _ := flakyAction(10)
+	engine.RegisterEvent(gre.Event{
+		Name:   "notify",
+		Mode:   gre.EventModeAsync,
+		Action: action,
+		Retry:  &gre.RetryPolicy{MaxAttempts: 2},
+	})
+
+	if err := engine.HandleEvent("notify", "rule", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if letters := sink.Letters(); len(letters) != 1 || letters[0].Attempts != 2 {
+		t.Errorf("Expected 1 dead letter after 2 attempts, got %v", letters)
+	}
+	if errs := reported.list(); len(errs) != 1 || !errors.Is(errs[0], errTransient) {
+		t.Errorf("Expected the action error to be reported, got %v", errs)
+	}
+}
+
+func TestEventRetry_AsyncOutlivesRunContext(t *testing.T) {
+	engine := gre.NewEngine()
+
+	var reported asyncErrors
+	engine.OnAsyncError(reported.add)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan struct{})
+	attempts := 0
+	var ctxErr error
+	engine.RegisterEvent(gre.Event{
+		Name: "notify",
+		Mode: gre.EventModeAsync,
+		Action: func(ectx gre.EventContext) error {
+			attempts++
+			if attempts == 1 {
+				// The run ends while the first attempt is in flight
+				<-canceled
+				return errTransient
+			}
+			ctxErr = ectx.Context.Err()
+			return nil
+		},
+		Retry: &gre.RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond},
+	})
+
+	if err := engine.HandleEventContext(ctx, "notify", "rule", true, gre.NewAlmanac(), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cancel()
+	close(canceled)
+
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if attempts != 2 || ctxErr != nil {
+		t.Errorf("Expected a second attempt with a live context, got %d attempts (context error %v)", attempts, ctxErr)
+	}
+	if errs := reported.list(); len(errs) != 0 {
+		t.Errorf("Expected no async error, got %v", errs)
+	}
+}
+
+func TestJSONLDeadLetterSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+	sink := gre.NewJSONLDeadLetterSink(path)
+
+	for _, name := range []string{"first", "second"} {
+		if err := sink.Write(gre.DeadLetter{EventName: name, RuleName: "rule", Attempts: 3, Error: "boom"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var letter gre.DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			t.Fatalf("Expected a JSON line, got %q: %v", scanner.Text(), err)
+		}
+		names = append(names, letter.EventName)
+	}
+	if len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Errorf("Expected the dead letters in order, got %v", names)
+	}
+
+	t.Run("unwritable file", func(t *testing.T) {
+		sink := gre.NewJSONLDeadLetterSink(filepath.Join(t.TempDir(), "missing", "dead-letters.jsonl"))
+		var engineErr *gre.RuleEngineError
+		if err := sink.Write(gre.DeadLetter{EventName: "event"}); !errors.As(err, &engineErr) || engineErr.Type != gre.ErrEvent {
+			t.Errorf("Expected an ErrEvent error, got %v", err)
+		}
+	})
+}
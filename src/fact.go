@@ -1,8 +1,10 @@
 package gorulesengine
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"fmt"
 	"reflect"
 )
 
@@ -18,6 +20,15 @@ const FactOptionKeyCache = "cache"
 // FactOptionKeyPriority is the key for the priority option in fact options.
 const FactOptionKeyPriority = "priority"
 
+// contextType is the reflected type of context.Context, used to detect context-aware dynamic facts.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// errorType is the reflected type of error, used to detect dynamic facts that can fail.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// paramsType is the reflected type of the params map passed to dynamic facts.
+var paramsType = reflect.TypeOf(map[string]interface{}{})
+
 // FactID is a unique identifier for a fact.
 type FactID string
 
@@ -33,12 +44,23 @@ type FactID string
 //	fact := gre.NewFact("temperature", func(params map[string]interface{}) interface{} {
 //	    return fetchTemperatureFromAPI()
 //	})
+//
+//	// Dynamic fact that can fail
+//	fact := gre.NewFact("balance", func(params map[string]interface{}) (interface{}, error) {
+//	    return db.Balance(params["accountID"])
+//	})
+//
+//	// Context-aware dynamic fact
+//	fact := gre.NewFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+//	    return fetchScore(ctx, params["userID"])
+//	})
 type Fact struct {
 	id            FactID
 	valueOrMethod interface{}
 	factType      string
 	options       map[string]interface{}
 	metadata      map[string]interface{}
+	err           error
 }
 
 // FactOption defines a functional option for configuring facts.
@@ -84,6 +106,10 @@ func WithMetadata(metadata map[string]interface{}) FactOption {
 // If valueOrMethod is a function, the fact is dynamic and will compute its value on demand.
 // Otherwise, the fact is static with a constant value.
 //
+// The signature of dynamic facts is validated here: an optional context.Context
+// followed by an optional params map, returning a value and optionally an error.
+// An unsupported signature is reported by Validate and by every computation.
+//
 // Options can be provided to customize caching and priority behavior.
 //
 // Example:
@@ -107,13 +133,22 @@ func NewFact(id FactID, valueOrMethod interface{}, opts ...FactOption) Fact {
 		metadata:      map[string]interface{}{},
 		factType: func() string {
 			// Use reflect to detect any function type
-			if reflect.TypeOf(valueOrMethod).Kind() == reflect.Func {
+			if reflect.ValueOf(valueOrMethod).Kind() == reflect.Func {
 				return DynamicFact
 			}
 			return ConstantFact
 		}(),
 	}
 
+	if fact.factType == DynamicFact {
+		if err := validateFactMethod(reflect.TypeOf(valueOrMethod)); err != nil {
+			fact.err = &FactError{
+				Fact: fact,
+				Err:  err,
+			}
+		}
+	}
+
 	// Default priority is 0
 	WithPriority(0)(&fact)
 
@@ -129,6 +164,40 @@ func NewFact(id FactID, valueOrMethod interface{}, opts ...FactOption) Fact {
 	return fact
 }
 
+// validateFactMethod checks that a dynamic fact function has a supported signature.
+func validateFactMethod(methodType reflect.Type) error {
+	if methodType.IsVariadic() {
+		return fmt.Errorf("unsupported dynamic fact signature %s: variadic functions are not supported", methodType)
+	}
+
+	in := 0
+	if in < methodType.NumIn() && methodType.In(in) == contextType {
+		in++
+	}
+	if in < methodType.NumIn() && methodType.In(in) == paramsType {
+		in++
+	}
+	if in != methodType.NumIn() {
+		return fmt.Errorf("unsupported dynamic fact signature %s: expected optional context.Context and map[string]interface{} parameters", methodType)
+	}
+
+	switch methodType.NumOut() {
+	case 1:
+		return nil
+	case 2:
+		if methodType.Out(1) == errorType {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported dynamic fact signature %s: expected a value and an optional error result", methodType)
+}
+
+// Validate returns the error detected when the fact was created, such as an
+// unsupported dynamic fact signature, or nil if the fact is valid.
+func (f *Fact) Validate() error {
+	return f.err
+}
+
 // ID returns the unique identifier of the fact.
 func (f *Fact) ID() FactID {
 	return f.id
@@ -176,33 +245,59 @@ func (f *Fact) GetCacheKey() (string, error) {
 	return "", nil
 }
 
-// Calculate executes the dynamic fact method or returns the constant fact value
+// Calculate executes the dynamic fact method or returns the constant fact value.
+// Errors are discarded; use CalculateContext to observe them.
 func (f *Fact) Calculate(params map[string]interface{}) interface{} {
+	val, _ := f.CalculateContext(context.Background(), params)
+	return val
+}
+
+// CalculateContext executes the dynamic fact method or returns the constant fact value.
+// Context-aware dynamic facts receive ctx, allowing slow computations to be cancelled.
+//
+// Supported signatures (T being any type):
+//
+//	func() T
+//	func() (T, error)
+//	func(params map[string]interface{}) T
+//	func(params map[string]interface{}) (T, error)
+//	func(ctx context.Context) T
+//	func(ctx context.Context) (T, error)
+//	func(ctx context.Context, params map[string]interface{}) T
+//	func(ctx context.Context, params map[string]interface{}) (T, error)
+func (f *Fact) CalculateContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
 	method := reflect.ValueOf(f.valueOrMethod)
-	methodType := method.Type()
 
 	// If it's not a function, return the value directly
-	methodKind := methodType.Kind()
-	if methodKind != reflect.Func {
-		return f.valueOrMethod
+	if method.Kind() != reflect.Func {
+		return f.valueOrMethod, nil
+	}
+
+	// Build arguments according to the validated signature
+	methodType := method.Type()
+	args := make([]reflect.Value, 0, methodType.NumIn())
+	for i := 0; i < methodType.NumIn(); i++ {
+		if methodType.In(i) == contextType {
+			args = append(args, reflect.ValueOf(&ctx).Elem())
+		} else {
+			args = append(args, reflect.ValueOf(params))
+		}
 	}
 
-	var results []reflect.Value
+	results := method.Call(args)
 
-	// Handle different method signatures
-	switch methodType.NumIn() {
-	case 0:
-		// Method with no parameters
-		results = method.Call([]reflect.Value{})
-	case 1:
-		// Method with one parameter (params)
-		results = method.Call([]reflect.Value{reflect.ValueOf(params)})
-	default:
-		// Unsupported signature
-		return nil
+	// A trailing error result reports a failed computation
+	if len(results) == 2 {
+		if err, _ := results[1].Interface().(error); err != nil {
+			return nil, err
+		}
 	}
 
-	return results[0].Interface()
+	return results[0].Interface(), nil
 }
 
 // hashFromID generates a unique MD5 hash based on the fact ID
@@ -1,6 +1,9 @@
 package gorulesengine_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	gre "github.com/deadelus/go-rules-engine/v2/src"
@@ -162,3 +165,130 @@ func TestFact_WithMetadata_Nil(t *testing.T) {
 		t.Errorf("Expected 'nil-coverage', got %v", fact.Metadata()["test"])
 	}
 }
+
+func TestFact_CalculateContext(t *testing.T) {
+	type ctxKey string
+
+	t.Run("passes context and params to context-aware facts", func(t *testing.T) {
+		fact := gre.NewFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return fmt.Sprintf("%v-%v", ctx.Value(ctxKey("tenant")), params["user"]), nil
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey("tenant"), "acme")
+		result, err := fact.CalculateContext(ctx, map[string]interface{}{"user": 42})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result != "acme-42" {
+			t.Errorf("Expected 'acme-42', got %v", result)
+		}
+	})
+
+	t.Run("returns the error of context-aware facts", func(t *testing.T) {
+		fact := gre.NewFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := fact.CalculateContext(ctx, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("calculate ignores the error", func(t *testing.T) {
+		fact := gre.NewFact("score", func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("boom")
+		})
+
+		if result := fact.Calculate(nil); result != nil {
+			t.Errorf("Expected nil, got %v", result)
+		}
+	})
+
+	t.Run("returns static values as-is", func(t *testing.T) {
+		fact := gre.NewFact("static", 7)
+
+		result, err := fact.CalculateContext(context.Background(), nil)
+		if err != nil || result != 7 {
+			t.Errorf("Expected 7 and no error, got %v, %v", result, err)
+		}
+	})
+}
+
+func TestFact_SupportedSignatures(t *testing.T) {
+	params := map[string]interface{}{"n": 2}
+	failure := errors.New("failure")
+
+	tests := []struct {
+		name     string
+		method   interface{}
+		expected interface{}
+		err      error
+	}{
+		{"no args", func() int { return 1 }, 1, nil},
+		{"no args with error", func() (int, error) { return 1, nil }, 1, nil},
+		{"params", func(p map[string]interface{}) interface{} { return p["n"] }, 2, nil},
+		{"params with error", func(p map[string]interface{}) (interface{}, error) { return p["n"], nil }, 2, nil},
+		{"params returning error", func(p map[string]interface{}) (interface{}, error) { return nil, failure }, nil, failure},
+		{"context", func(ctx context.Context) string { return "ctx" }, "ctx", nil},
+		{"context with error", func(ctx context.Context) (string, error) { return "", failure }, nil, failure},
+		{"context and params", func(ctx context.Context, p map[string]interface{}) interface{} { return p["n"] }, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fact := gre.NewFact("test", tt.method)
+			if err := fact.Validate(); err != nil {
+				t.Fatalf("Expected valid signature, got %v", err)
+			}
+
+			result, err := fact.CalculateContext(context.Background(), params)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFact_Validate_UnsupportedSignatures(t *testing.T) {
+	tests := []struct {
+		name   string
+		method interface{}
+	}{
+		{"two plain args", func(a int, b int) int { return a + b }},
+		{"wrong param type", func(p map[string]string) int { return 0 }},
+		{"params before context", func(p map[string]interface{}, ctx context.Context) int { return 0 }},
+		{"no result", func() {}},
+		{"second result not error", func() (int, int) { return 0, 0 }},
+		{"too many results", func() (int, int, error) { return 0, 0, nil }},
+		{"variadic", func(args ...interface{}) int { return 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fact := gre.NewFact("test", tt.method)
+
+			var factErr *gre.FactError
+			if !errors.As(fact.Validate(), &factErr) {
+				t.Fatalf("Expected FactError, got %v", fact.Validate())
+			}
+
+			if _, err := fact.CalculateContext(context.Background(), nil); !errors.As(err, &factErr) {
+				t.Errorf("Expected computation to report the FactError, got %v", err)
+			}
+		})
+	}
+
+	t.Run("static facts are always valid", func(t *testing.T) {
+		fact := gre.NewFact("test", nil)
+		if err := fact.Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package gorulesengine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RuleEffect tells the decision a matching rule votes for under the
+// deny-overrides and permit-overrides hit policies.
+type RuleEffect string
+
+const (
+	// RuleEffectPermit votes for DecisionAuthorize when the rule matches (the default).
+	RuleEffectPermit RuleEffect = "permit"
+	// RuleEffectDeny votes for DecisionDecline when the rule matches.
+	RuleEffectDeny RuleEffect = "deny"
+)
+
+// Names of the built-in hit policies, as reported in EngineResponse.Policy.
+const (
+	// HitPolicyAny authorizes if any rule matched (the default).
+	HitPolicyAny = "any"
+	// HitPolicyFirst decides with the first matching rule by priority.
+	HitPolicyFirst = "first"
+	// HitPolicyUnique authorizes if exactly one rule matched, and fails if several did.
+	HitPolicyUnique = "unique"
+	// HitPolicyAll authorizes only if every rule matched.
+	HitPolicyAll = "all"
+	// HitPolicyScore authorizes if the weights of the matching rules reach a threshold.
+	HitPolicyScore = "score"
+	// HitPolicyDenyOverrides declines if any deny rule matched, else authorizes if any permit rule matched.
+	HitPolicyDenyOverrides = "deny_overrides"
+	// HitPolicyPermitOverrides authorizes if any permit rule matched, else declines.
+	HitPolicyPermitOverrides = "permit_overrides"
+)
+
+// HitPolicy decides the outcome of a run from its rule results, in the manner of
+// DMN hit policies. Results are passed in a deterministic order: by descending
+// priority, then by name.
+type HitPolicy interface {
+	// Name identifies the policy in EngineResponse.Policy.
+	Name() string
+	// Decide returns the decision and the rules that determined it.
+	// An error means the policy could not decide (e.g. several matches under unique).
+	Decide(results []*RuleResult) (HitPolicyResult, error)
+}
+
+// HitPolicyResult is the outcome of a HitPolicy.
+type HitPolicyResult struct {
+	Decision string   // DecisionAuthorize or DecisionDecline
+	Rules    []string // Rules that determined the decision
+	Score    *float64 // Total weight of the matching rules, for score-based policies
+}
+
+// hitPolicy adapts a decision function to the HitPolicy interface.
+type hitPolicy struct {
+	name   string
+	decide func(results []*RuleResult) (HitPolicyResult, error)
+}
+
+func (p hitPolicy) Name() string { return p.name }
+
+func (p hitPolicy) Decide(results []*RuleResult) (HitPolicyResult, error) {
+	return p.decide(results)
+}
+
+// AnyMatchPolicy authorizes if any rule matched; the matching rules decide.
+func AnyMatchPolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyAny, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		matched := matchingRules(results, nil)
+		return HitPolicyResult{Decision: decisionFor(len(matched) > 0), Rules: matched}, nil
+	}}
+}
+
+// FirstMatchPolicy authorizes if any rule matched; the first matching rule by priority decides.
+func FirstMatchPolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyFirst, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		matched := matchingRules(results, nil)
+		if len(matched) == 0 {
+			return HitPolicyResult{Decision: DecisionDecline, Rules: []string{}}, nil
+		}
+		return HitPolicyResult{Decision: DecisionAuthorize, Rules: matched[:1]}, nil
+	}}
+}
+
+// UniquePolicy authorizes if exactly one rule matched. Several matching rules
+// are an error: the decision is declined and the conflicting rules are reported.
+func UniquePolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyUnique, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		matched := matchingRules(results, nil)
+		if len(matched) > 1 {
+			return HitPolicyResult{Decision: DecisionDecline, Rules: matched}, &RuleEngineError{
+				Type: ErrEngine,
+				Msg:  fmt.Sprintf("unique hit policy violated: %d rules matched (%s)", len(matched), strings.Join(matched, ", ")),
+			}
+		}
+		return HitPolicyResult{Decision: decisionFor(len(matched) == 1), Rules: matched}, nil
+	}}
+}
+
+// AllPassPolicy authorizes only if every rule matched. Otherwise, the rules
+// that did not match decide.
+func AllPassPolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyAll, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		failed := []string{}
+		for _, result := range results {
+			if !result.Result {
+				failed = append(failed, result.Name)
+			}
+		}
+		if len(failed) > 0 {
+			return HitPolicyResult{Decision: DecisionDecline, Rules: failed}, nil
+		}
+		return HitPolicyResult{Decision: DecisionAuthorize, Rules: matchingRules(results, nil)}, nil
+	}}
+}
+
+// ScoreThresholdPolicy authorizes if the total weight of the matching rules
+// reaches threshold. Rules missing from weights weigh 1.
+func ScoreThresholdPolicy(threshold float64, weights map[string]float64) HitPolicy {
+	return hitPolicy{name: HitPolicyScore, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		matched := matchingRules(results, nil)
+		score := 0.0
+		for _, name := range matched {
+			weight, ok := weights[name]
+			if !ok {
+				weight = 1
+			}
+			score += weight
+		}
+		return HitPolicyResult{Decision: decisionFor(score >= threshold), Rules: matched, Score: &score}, nil
+	}}
+}
+
+// DenyOverridesPolicy declines if any rule with RuleEffectDeny matched, else
+// authorizes if any permit rule matched, else declines.
+func DenyOverridesPolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyDenyOverrides, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		if denied := matchingRules(results, isDenyRule); len(denied) > 0 {
+			return HitPolicyResult{Decision: DecisionDecline, Rules: denied}, nil
+		}
+		permitted := matchingRules(results, isPermitRule)
+		return HitPolicyResult{Decision: decisionFor(len(permitted) > 0), Rules: permitted}, nil
+	}}
+}
+
+// PermitOverridesPolicy authorizes if any rule with RuleEffectPermit (or no effect)
+// matched, else declines, with the matching deny rules deciding.
+func PermitOverridesPolicy() HitPolicy {
+	return hitPolicy{name: HitPolicyPermitOverrides, decide: func(results []*RuleResult) (HitPolicyResult, error) {
+		if permitted := matchingRules(results, isPermitRule); len(permitted) > 0 {
+			return HitPolicyResult{Decision: DecisionAuthorize, Rules: permitted}, nil
+		}
+		return HitPolicyResult{Decision: DecisionDecline, Rules: matchingRules(results, isDenyRule)}, nil
+	}}
+}
+
+// matchingRules returns the names of the matching rules accepted by filter (all if nil).
+func matchingRules(results []*RuleResult, filter func(*RuleResult) bool) []string {
+	names := []string{}
+	for _, result := range results {
+		if result.Result && (filter == nil || filter(result)) {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+func isDenyRule(result *RuleResult) bool {
+	return result.Effect == RuleEffectDeny
+}
+
+func isPermitRule(result *RuleResult) bool {
+	return result.Effect != RuleEffectDeny
+}
+
+// decisionFor converts a boolean outcome to DecisionAuthorize or DecisionDecline.
+func decisionFor(authorized bool) string {
+	if authorized {
+		return DecisionAuthorize
+	}
+	return DecisionDecline
+}
+
+// sortedRuleResults returns the results by descending priority, then by name.
+// Rules not evaluated because the run halted are left out, as in ReduceResults.
+func sortedRuleResults(results map[string]*RuleResult) []*RuleResult {
+	sorted := make([]*RuleResult, 0, len(results))
+	for _, result := range results {
+		if result.Status == RuleStatusNotEvaluated {
+			continue
+		}
+		sorted = append(sorted, result)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
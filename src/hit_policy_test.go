@@ -0,0 +1,201 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// newPolicyRun evaluates rules matching or not according to matches.
+func newPolicyRun(t *testing.T, opts []gre.EngineOption, rules ...*gre.Rule) *gre.RunResult {
+	t.Helper()
+	engine := gre.NewEngine(opts...)
+	engine.AddRules(rules...)
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("match", true)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return run
+}
+
+// policyRule creates a rule that matches or not, with the given priority and effect.
+func policyRule(name string, priority int, matches bool, effect gre.RuleEffect) *gre.Rule {
+	return &gre.Rule{
+		Name:       name,
+		Priority:   priority,
+		Effect:     effect,
+		Conditions: gre.All(gre.Equal("match", matches)),
+		OnSuccess:  []gre.RuleEvent{{Name: name + "-matched"}},
+	}
+}
+
+func TestHitPolicies(t *testing.T) {
+	rules := []*gre.Rule{
+		policyRule("fraud", 100, true, gre.RuleEffectDeny),
+		policyRule("kyc", 50, true, ""),
+		policyRule("vip", 50, false, gre.RuleEffectPermit),
+		policyRule("newsletter", 10, true, gre.RuleEffectPermit),
+	}
+	run := newPolicyRun(t, nil, rules...)
+
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		policy   gre.HitPolicy
+		name     string
+		decision string
+		rules    []string
+		score    *float64
+		err      string
+	}{
+		{gre.AnyMatchPolicy(), gre.HitPolicyAny, gre.DecisionAuthorize, []string{"fraud", "kyc", "newsletter"}, nil, ""},
+		{gre.FirstMatchPolicy(), gre.HitPolicyFirst, gre.DecisionAuthorize, []string{"fraud"}, nil, ""},
+		{gre.UniquePolicy(), gre.HitPolicyUnique, gre.DecisionDecline, []string{"fraud", "kyc", "newsletter"}, nil, "unique hit policy violated: 3 rules matched"},
+		{gre.AllPassPolicy(), gre.HitPolicyAll, gre.DecisionDecline, []string{"vip"}, nil, ""},
+		{gre.ScoreThresholdPolicy(5, map[string]float64{"kyc": 4, "fraud": -10}), gre.HitPolicyScore, gre.DecisionDecline, []string{"fraud", "kyc", "newsletter"}, score(-5), ""},
+		{gre.ScoreThresholdPolicy(5, map[string]float64{"kyc": 4, "fraud": 0}), gre.HitPolicyScore, gre.DecisionAuthorize, []string{"fraud", "kyc", "newsletter"}, score(5), ""},
+		{gre.DenyOverridesPolicy(), gre.HitPolicyDenyOverrides, gre.DecisionDecline, []string{"fraud"}, nil, ""},
+		{gre.PermitOverridesPolicy(), gre.HitPolicyPermitOverrides, gre.DecisionAuthorize, []string{"kyc", "newsletter"}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := run.GenerateResponseWithPolicy(tt.policy)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) || !strings.Contains(res.Error, tt.err) {
+					t.Fatalf("Expected error %q, got %v (response %q)", tt.err, err, res.Error)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if res.Policy != tt.name {
+				t.Errorf("Expected policy %s, got %s", tt.name, res.Policy)
+			}
+			if res.Decision != tt.decision {
+				t.Errorf("Expected decision %s, got %s", tt.decision, res.Decision)
+			}
+			if !reflect.DeepEqual(res.DecidingRules, tt.rules) {
+				t.Errorf("Expected deciding rules %v, got %v", tt.rules, res.DecidingRules)
+			}
+			if !reflect.DeepEqual(res.Score, tt.score) {
+				t.Errorf("Expected score %v, got %v", tt.score, res.Score)
+			}
+		})
+	}
+}
+
+func TestHitPolicies_NoMatch(t *testing.T) {
+	run := newPolicyRun(t, nil, policyRule("a", 1, false, ""), policyRule("b", 1, false, gre.RuleEffectDeny))
+
+	for _, policy := range []gre.HitPolicy{
+		gre.AnyMatchPolicy(), gre.FirstMatchPolicy(), gre.UniquePolicy(),
+		gre.DenyOverridesPolicy(), gre.PermitOverridesPolicy(), gre.ScoreThresholdPolicy(1, nil),
+	} {
+		res, err := run.GenerateResponseWithPolicy(policy)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", policy.Name(), err)
+		}
+		if res.Decision != gre.DecisionDecline || len(res.DecidingRules) != 0 {
+			t.Errorf("%s: expected a decline without deciding rules, got %s %v", policy.Name(), res.Decision, res.DecidingRules)
+		}
+		if res.Reason != "Rule 'a' determined the result" {
+			t.Errorf("%s: expected the first rule to explain the result, got %v", policy.Name(), res.Reason)
+		}
+	}
+}
+
+func TestHitPolicy_EngineOption(t *testing.T) {
+	rules := []*gre.Rule{
+		policyRule("allow", 10, true, gre.RuleEffectPermit),
+		policyRule("block", 5, true, gre.RuleEffectDeny),
+	}
+
+	run := newPolicyRun(t, []gre.EngineOption{gre.WithHitPolicy(gre.DenyOverridesPolicy())}, rules...)
+	res := run.GenerateResponse()
+	if res.Policy != gre.HitPolicyDenyOverrides || res.Decision != gre.DecisionDecline {
+		t.Errorf("Expected a deny_overrides decline, got %s %s", res.Policy, res.Decision)
+	}
+	if res.Reason != "Rule 'block' determined the result" {
+		t.Errorf("Expected the deciding rule to explain the result, got %v", res.Reason)
+	}
+
+	// Per-run policy takes over the engine policy
+	res, _ = run.GenerateResponseWithPolicy(gre.PermitOverridesPolicy())
+	if res.Decision != gre.DecisionAuthorize {
+		t.Errorf("Expected permit_overrides to authorize, got %s", res.Decision)
+	}
+
+	// Engine.GenerateResponse uses the engine policy too
+	engine := gre.NewEngine(gre.WithHitPolicy(gre.UniquePolicy()))
+	engine.AddRules(rules...)
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("match", true)
+	if _, err := engine.Run(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	res = engine.GenerateResponse()
+	if res.Policy != gre.HitPolicyUnique || res.Error == "" || res.Decision != gre.DecisionDecline {
+		t.Errorf("Expected a unique policy violation, got %+v", res)
+	}
+}
+
+func TestGenerateResponse_Deterministic(t *testing.T) {
+	rules := []*gre.Rule{
+		policyRule("charlie", 10, true, ""),
+		policyRule("alpha", 10, true, ""),
+		policyRule("bravo", 10, true, ""),
+	}
+
+	for i := 0; i < 20; i++ {
+		res := newPolicyRun(t, nil, rules...).GenerateResponse()
+		if res.Reason != "Rule 'alpha' determined the result" {
+			t.Fatalf("Expected rule 'alpha' to explain the result, got %v", res.Reason)
+		}
+		var events []string
+		for _, ev := range res.Events {
+			events = append(events, ev.Type)
+		}
+		expected := []string{"alpha-matched", "bravo-matched", "charlie-matched"}
+		if !reflect.DeepEqual(events, expected) {
+			t.Fatalf("Expected events %v, got %v", expected, events)
+		}
+	}
+}
+
+func TestRuleEffect_Serialization(t *testing.T) {
+	rule := gre.NewRuleBuilder().
+		WithName("block").
+		WithEffect(gre.RuleEffectDeny).
+		WithConditions(gre.ConditionNode{Condition: gre.Equal("match", true)}).
+		Build()
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), `"effect":"deny"`) {
+		t.Errorf("Expected the effect to be serialized, got %s", data)
+	}
+
+	var decoded gre.Rule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.Effect != gre.RuleEffectDeny {
+		t.Errorf("Expected effect deny, got %q", decoded.Effect)
+	}
+
+	decoded.Effect = "maybe"
+	diags := gre.ValidateRule(&decoded)
+	if len(diags) != 1 || diags[0].Code != gre.DiagnosticInvalidEffect || diags[0].Path != "$.effect" {
+		t.Errorf("Expected an INVALID_EFFECT diagnostic, got %v", diags)
+	}
+}
@@ -0,0 +1,298 @@
+package gorulesengine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetFactEventName is the name of the built-in event asserting a fact into the almanac.
+// Its params are "fact" (the ID of the fact to set) and "value" (a static value, or a
+// fact reference {"fact": ..., "path": ...} copying the value of another fact).
+// A registered event with the same name takes precedence over the built-in one.
+const SetFactEventName = "setFact"
+
+// defaultMaxIterations is the number of evaluation passes allowed by WithForwardChaining
+// when no positive limit is given.
+const defaultMaxIterations = 10
+
+// FactAssertion records a fact asserted while the engine runs in forward chaining mode.
+type FactAssertion struct {
+	Rule      string      `json:"rule"`            // Rule whose event asserted the fact (empty outside of rule events)
+	Fact      FactID      `json:"fact"`            // ID of the asserted fact
+	Value     interface{} `json:"value,omitempty"` // Asserted value (nil for dynamic facts)
+	Iteration int         `json:"iteration"`       // Evaluation pass in which the fact was asserted (1 for the initial pass)
+}
+
+// factTracker records the facts changed in an almanac during forward chaining.
+type factTracker struct {
+	rule       string          // Rule whose events are being handled
+	iteration  int             // Current evaluation pass
+	changed    map[FactID]bool // Facts changed since the last call to takeFactChanges
+	assertions []FactAssertion // Audit trail of all assertions
+}
+
+// record registers the assertion of a new or changed fact.
+func (t *factTracker) record(fact *Fact) {
+	var value interface{}
+	if !fact.IsDynamic() {
+		value = fact.ValueOrMethod()
+	}
+	t.changed[fact.ID()] = true
+	t.assertions = append(t.assertions, FactAssertion{
+		Rule:      t.rule,
+		Fact:      fact.ID(),
+		Value:     value,
+		Iteration: t.iteration,
+	})
+}
+
+// startFactTracking starts recording the facts added or changed in the almanac.
+func (a *Almanac) startFactTracking() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.tracker = &factTracker{iteration: 1, changed: make(map[FactID]bool)}
+}
+
+// stopFactTracking stops recording fact changes and returns the audit trail.
+func (a *Almanac) stopFactTracking() []FactAssertion {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.tracker == nil {
+		return nil
+	}
+	assertions := a.tracker.assertions
+	a.tracker = nil
+	return assertions
+}
+
+// trackRule attributes the next assertions to the given rule.
+func (a *Almanac) trackRule(rule string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.tracker != nil {
+		a.tracker.rule = rule
+	}
+}
+
+// trackIteration attributes the next assertions to the given evaluation pass.
+func (a *Almanac) trackIteration(iteration int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.tracker != nil {
+		a.tracker.iteration = iteration
+	}
+}
+
+// takeFactChanges returns the facts changed since the last call and resets the set.
+func (a *Almanac) takeFactChanges() map[FactID]bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.tracker == nil || len(a.tracker.changed) == 0 {
+		return nil
+	}
+	changed := a.tracker.changed
+	a.tracker.changed = make(map[FactID]bool)
+	return changed
+}
+
+// assertedFacts returns the IDs of all facts asserted so far, in assertion order.
+func (a *Almanac) assertedFacts() []FactID {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if a.tracker == nil {
+		return nil
+	}
+	seen := make(map[FactID]bool)
+	var ids []FactID
+	for _, assertion := range a.tracker.assertions {
+		if !seen[assertion.Fact] {
+			seen[assertion.Fact] = true
+			ids = append(ids, assertion.Fact)
+		}
+	}
+	return ids
+}
+
+// forwardChainingOptions returns the iteration limit and whether forward chaining is enabled.
+func forwardChainingOptions(options map[string]interface{}) (int, bool) {
+	if enabled, ok := options[EngineOptionKeyForwardChaining].(bool); !ok || !enabled {
+		return 0, false
+	}
+	maxIterations, _ := options[EngineOptionKeyMaxIterations].(int)
+	if maxIterations <= 0 {
+		maxIterations = defaultMaxIterations
+	}
+	return maxIterations, true
+}
+
+// runForwardChaining re-evaluates the rules depending on the facts asserted by the
+// previous pass, firing their events again, until no fact changes (a fixpoint).
+// Asserting a fact with its current value is not a change. Rules are passed in
+// evaluation order. It fails when maxIterations passes are not enough, or when a
+// pass would repeat the state of an earlier one (the assertions loop forever).
+// A halted run is not re-evaluated.
+func (e *Engine) runForwardChaining(ctx context.Context, run *RunResult, rules []*Rule, options map[string]interface{}, metrics MetricsCollector, maxIterations int) error {
+	almanac := run.almanac
+	states := make(map[string]int)
+
+	for {
+		changed := almanac.takeFactChanges()
+		if len(changed) == 0 || run.haltedBy != "" {
+			return nil
+		}
+
+		if state, ok := run.inferenceState(changed); ok {
+			if previous, seen := states[state]; seen {
+				return &RuleEngineError{
+					Type: ErrEngine,
+					Msg:  fmt.Sprintf("forward chaining loop: iteration %d repeats the state of iteration %d (facts %s)", run.iterations, previous, formatFactIDs(changed)),
+				}
+			}
+			states[state] = run.iterations
+		}
+
+		if run.iterations >= maxIterations {
+			return &RuleEngineError{
+				Type: ErrEngine,
+				Msg:  fmt.Sprintf("forward chaining did not reach a fixpoint after %d iterations (facts %s still changing)", maxIterations, formatFactIDs(changed)),
+			}
+		}
+
+		run.iterations++
+		almanac.trackIteration(run.iterations)
+
+		affected := rulesAffectedBy(rules, changed)
+		if len(affected) == 0 {
+			continue
+		}
+		if err := e.runRules(ctx, run, affected, options, metrics); err != nil {
+			return err
+		}
+	}
+}
+
+// rulesAffectedBy returns the rules requiring one of the changed facts, plus the
+// rules depending on them through rule conditions, in their original order.
+func rulesAffectedBy(rules []*Rule, changed map[FactID]bool) []*Rule {
+	g := newRuleGraph(rules)
+	dependents := make([][]int, len(rules))
+	for i, deps := range g.deps {
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	affected := make([]bool, len(rules))
+	var mark func(i int)
+	mark = func(i int) {
+		if affected[i] {
+			return
+		}
+		affected[i] = true
+		for _, j := range dependents[i] {
+			mark(j)
+		}
+	}
+
+	for i, rule := range rules {
+		for _, id := range rule.GetRequiredFacts() {
+			if changed[id] {
+				mark(i)
+				break
+			}
+		}
+	}
+
+	var result []*Rule
+	for i, rule := range rules {
+		if affected[i] {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// inferenceState returns a key identifying the state of a forward chaining run:
+// the rule outcomes, the values of the asserted facts and the facts still to
+// propagate. It returns false when the state cannot be captured (dynamic or
+// non-serializable facts), in which case only the iteration limit applies.
+func (r *RunResult) inferenceState(changed map[FactID]bool) (string, bool) {
+	state := struct {
+		Rules   map[string]bool        `json:"rules"`
+		Facts   map[FactID]interface{} `json:"facts"`
+		Pending string                 `json:"pending"`
+	}{
+		Rules:   r.ReduceResults(),
+		Facts:   make(map[FactID]interface{}),
+		Pending: formatFactIDs(changed),
+	}
+
+	facts := r.almanac.GetFacts()
+	for _, id := range r.almanac.assertedFacts() {
+		fact, ok := facts[id]
+		if !ok || fact.IsDynamic() {
+			return "", false
+		}
+		state.Facts[id] = fact.ValueOrMethod()
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// assertFactFromParams handles the built-in setFact event and returns the asserted value.
+// A fact reference value is always resolved; templates only with event param resolution.
+func assertFactFromParams(ctx context.Context, almanac *Almanac, params map[string]interface{}, resolveParams bool) (interface{}, error) {
+	id, _ := params["fact"].(string)
+	if id == "" {
+		return nil, &RuleEngineError{
+			Type: ErrEvent,
+			Msg:  fmt.Sprintf("event '%s' requires a non-empty 'fact' parameter", SetFactEventName),
+		}
+	}
+	if almanac == nil {
+		return nil, &RuleEngineError{
+			Type: ErrEvent,
+			Msg:  fmt.Sprintf("event '%s' cannot set fact '%s' without an almanac", SetFactEventName, id),
+		}
+	}
+
+	value := params["value"]
+	var err error
+	if ref, ok := asFactReference(value); ok {
+		value, err = resolveParamFact(ctx, almanac, ref.Fact, ref.Params, ref.Path)
+	} else if resolveParams {
+		value, err = resolveParamValue(ctx, almanac, value)
+	}
+	if err != nil {
+		return nil, &RuleEngineError{
+			Type: ErrEvent,
+			Msg:  fmt.Sprintf("event '%s' cannot resolve the value of fact '%s'", SetFactEventName, id),
+			Err:  err,
+		}
+	}
+
+	return value, almanac.AddFact(FactID(id), value)
+}
+
+// formatFactIDs formats a set of fact IDs as a sorted, comma-separated list.
+func formatFactIDs(ids map[FactID]bool) string {
+	names := make([]string, 0, len(ids))
+	for id := range ids {
+		names = append(names, string(id))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,347 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// setFact creates a built-in setFact event.
+func setFact(fact string, value interface{}) gre.RuleEvent {
+	return gre.RuleEvent{Name: gre.SetFactEventName, Params: map[string]interface{}{"fact": fact, "value": value}}
+}
+
+func TestForwardChaining_SetFact(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+		"cached":     {gre.WithConditionCaching()},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			engine := gre.NewEngine(append(opts, gre.WithForwardChaining(0))...)
+
+			var fired []string
+			engine.RegisterEvent(gre.Event{
+				Name: "record",
+				Action: func(ctx gre.EventContext) error {
+					fired = append(fired, ctx.RuleName)
+					return nil
+				},
+			})
+			rules := []*gre.Rule{
+				{
+					Name:       "eligible-notice",
+					Priority:   30,
+					Conditions: gre.All(gre.Equal("eligible", true)),
+					OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+				},
+				{
+					Name:       "can-vote",
+					Priority:   20,
+					Conditions: gre.All(gre.Equal("isAdult", true), gre.Equal("country", "FR")),
+					OnSuccess:  []gre.RuleEvent{setFact("eligible", true), {Name: "record"}},
+				},
+				{
+					Name:       "adult",
+					Priority:   10,
+					Conditions: gre.All(gre.GreaterThanInclusive("age", 18)),
+					OnSuccess:  []gre.RuleEvent{setFact("isAdult", true), {Name: "record"}},
+				},
+			}
+			if err := engine.TryAddRules(rules...); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("age", 30)
+			almanac.AddFact("country", "FR")
+			almanac.AddFact("isAdult", false, gre.WithCache())
+			almanac.AddFact("eligible", false)
+
+			run, err := engine.Evaluate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			expectedResults := map[string]bool{"adult": true, "can-vote": true, "eligible-notice": true}
+			if got := run.ReduceResults(); !reflect.DeepEqual(got, expectedResults) {
+				t.Errorf("Expected results %v, got %v", expectedResults, got)
+			}
+			if run.Iterations() != 3 {
+				t.Errorf("Expected 3 iterations, got %d", run.Iterations())
+			}
+
+			expectedFired := []string{"adult", "can-vote", "eligible-notice"}
+			if !reflect.DeepEqual(fired, expectedFired) {
+				t.Errorf("Expected events fired by %v, got %v", expectedFired, fired)
+			}
+
+			expectedAssertions := []gre.FactAssertion{
+				{Rule: "adult", Fact: "isAdult", Value: true, Iteration: 1},
+				{Rule: "can-vote", Fact: "eligible", Value: true, Iteration: 2},
+			}
+			if !reflect.DeepEqual(run.Assertions(), expectedAssertions) {
+				t.Errorf("Expected assertions %+v, got %+v", expectedAssertions, run.Assertions())
+			}
+		})
+	}
+}
+
+func TestForwardChaining_EventAction(t *testing.T) {
+	engine := gre.NewEngine(gre.WithForwardChaining(5))
+	engine.RegisterEvent(gre.Event{
+		Name: "apply-discount",
+		Action: func(ctx gre.EventContext) error {
+			return ctx.Almanac.AddFact("discount", 10)
+		},
+	})
+	engine.AddRules(
+		&gre.Rule{
+			Name:       "vip",
+			Conditions: gre.All(gre.Equal("tier", "vip")),
+			OnSuccess:  []gre.RuleEvent{{Name: "apply-discount"}},
+		},
+		&gre.Rule{
+			Name:       "discounted",
+			Conditions: gre.All(gre.GreaterThan("discount", 0)),
+		},
+	)
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("tier", "vip")
+	almanac.AddFact("discount", 0)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !run.Results()["discounted"].Result {
+		t.Error("Expected 'discounted' to be re-evaluated to true")
+	}
+	expected := []gre.FactAssertion{{Rule: "vip", Fact: "discount", Value: 10, Iteration: 1}}
+	if !reflect.DeepEqual(run.Assertions(), expected) {
+		t.Errorf("Expected assertions %+v, got %+v", expected, run.Assertions())
+	}
+}
+
+func TestForwardChaining_Disabled(t *testing.T) {
+	engine := gre.NewEngine()
+	engine.AddRules(
+		&gre.Rule{
+			Name:       "can-vote",
+			Priority:   20,
+			Conditions: gre.All(gre.Equal("isAdult", true), gre.Equal("country", "FR")),
+			OnSuccess:  []gre.RuleEvent{setFact("eligible", true), {Name: "record"}},
+		},
+		&gre.Rule{
+			Name:       "adult",
+			Priority:   10,
+			Conditions: gre.All(gre.GreaterThanInclusive("age", 18)),
+			OnSuccess:  []gre.RuleEvent{setFact("isAdult", true), {Name: "record"}},
+		},
+	)
+	engine.RegisterEvent(gre.Event{Name: "record"})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("age", 30)
+	almanac.AddFact("country", "FR")
+	almanac.AddFact("isAdult", false)
+	almanac.AddFact("eligible", false)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// setFact still asserts the fact, but no rule is re-evaluated
+	if value, _ := almanac.GetFactValue("isAdult", nil, ""); value != true {
+		t.Errorf("Expected isAdult to be set, got %v", value)
+	}
+	if run.Results()["can-vote"].Result {
+		t.Error("Expected 'can-vote' not to be re-evaluated")
+	}
+	if run.Iterations() != 1 {
+		t.Errorf("Expected 1 iteration, got %d", run.Iterations())
+	}
+	if run.Assertions() != nil {
+		t.Errorf("Expected no assertions, got %+v", run.Assertions())
+	}
+}
+
+func TestForwardChaining_OnlyAffectedRules(t *testing.T) {
+	engine := gre.NewEngine(gre.WithForwardChaining(0))
+	engine.AddRules(
+		&gre.Rule{
+			Name:       "adult",
+			Conditions: gre.All(gre.GreaterThanInclusive("age", 18)),
+			OnSuccess:  []gre.RuleEvent{setFact("isAdult", true)},
+		},
+		&gre.Rule{
+			Name:       "weather",
+			Conditions: gre.All(gre.GreaterThan("temperature", 20)),
+		},
+		&gre.Rule{
+			Name:       "adult-check",
+			Conditions: gre.All(gre.Equal("isAdult", true)),
+		},
+		&gre.Rule{
+			Name:       "adult-audit",
+			Conditions: gre.All(ruleRef("adult-check", true)),
+		},
+	)
+
+	calls := 0
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("age", 20)
+	almanac.AddFact("isAdult", false)
+	almanac.AddFact("temperature", func() int {
+		calls++
+		return 25
+	})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the unaffected rule to be evaluated once, got %d evaluations", calls)
+	}
+	if !run.Results()["adult-audit"].Result {
+		t.Error("Expected the rule depending on 'adult-check' to be re-evaluated")
+	}
+}
+
+func TestForwardChaining_MaxIterations(t *testing.T) {
+	engine := gre.NewEngine(gre.WithForwardChaining(5))
+	engine.RegisterEvent(gre.Event{
+		Name: "increment",
+		Action: func(ctx gre.EventContext) error {
+			counter, _ := ctx.Almanac.GetFactValue("counter", nil, "")
+			return ctx.Almanac.AddFact("counter", counter.(int)+1)
+		},
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "count",
+		Conditions: gre.All(gre.LessThan("counter", 100)),
+		OnSuccess:  []gre.RuleEvent{{Name: "increment"}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("counter", 0)
+
+	run, err := engine.Evaluate(almanac)
+	if err == nil || !strings.Contains(err.Error(), "fixpoint after 5 iterations") {
+		t.Fatalf("Expected a max iterations error, got %v", err)
+	}
+	if run.Iterations() != 5 {
+		t.Errorf("Expected 5 iterations, got %d", run.Iterations())
+	}
+	if len(run.Assertions()) != 5 {
+		t.Errorf("Expected 5 assertions, got %d", len(run.Assertions()))
+	}
+}
+
+func TestForwardChaining_LoopDetection(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%v", parallel), func(t *testing.T) {
+			opts := []gre.EngineOption{gre.WithForwardChaining(100)}
+			if parallel {
+				opts = append(opts, gre.WithParallelExecution(2))
+			}
+			engine := gre.NewEngine(opts...)
+			engine.AddRules(
+				&gre.Rule{
+					Name:       "on",
+					Conditions: gre.All(gre.Equal("switch", 0)),
+					OnSuccess:  []gre.RuleEvent{setFact("switch", 1)},
+				},
+				&gre.Rule{
+					Name:       "off",
+					Conditions: gre.All(gre.Equal("switch", 1)),
+					OnSuccess:  []gre.RuleEvent{setFact("switch", 0)},
+				},
+			)
+
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("switch", 0)
+
+			run, err := engine.Evaluate(almanac)
+			if err == nil || !strings.Contains(err.Error(), "forward chaining loop") {
+				t.Fatalf("Expected a loop error, got %v", err)
+			}
+			if run.Iterations() >= 100 {
+				t.Errorf("Expected the loop to be detected early, got %d iterations", run.Iterations())
+			}
+		})
+	}
+}
+
+func TestForwardChaining_SetFactFromJSON(t *testing.T) {
+	data := `[
+		{
+			"name": "total",
+			"conditions": {"all": [{"fact": "order", "path": "$.amount", "operator": "greater_than", "value": 0}]},
+			"onSuccess": [{"name": "setFact", "params": {"fact": "total", "value": {"fact": "order", "path": "$.amount"}}}]
+		},
+		{
+			"name": "large-order",
+			"conditions": {"all": [{"fact": "total", "operator": "greater_than", "value": 100}]}
+		}
+	]`
+
+	var rules []*gre.Rule
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	engine := gre.NewEngine(gre.WithForwardChaining(0))
+	if err := engine.TryAddRules(rules...); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if diags := engine.ValidateRules(); len(diags) != 0 {
+		t.Errorf("Expected no diagnostics for the built-in event, got %v", diags)
+	}
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("order", map[string]interface{}{"amount": 250.0})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !run.Results()["large-order"].Result {
+		t.Error("Expected 'large-order' to match the asserted total")
+	}
+	if value, _ := almanac.GetFactValue("total", nil, ""); value != 250.0 {
+		t.Errorf("Expected total 250, got %v", value)
+	}
+}
+
+func TestForwardChaining_InvalidSetFact(t *testing.T) {
+	rule := &gre.Rule{
+		Name:       "invalid",
+		Conditions: gre.All(gre.Equal("age", 18)),
+		OnSuccess:  []gre.RuleEvent{{Name: gre.SetFactEventName, Params: map[string]interface{}{"value": 1}}},
+	}
+
+	diags := gre.ValidateRule(rule)
+	if len(diags) != 1 || diags[0].Code != gre.DiagnosticInvalidEventParams || diags[0].Path != "$.onSuccess[0].params.fact" {
+		t.Fatalf("Expected an INVALID_EVENT_PARAMS diagnostic, got %v", diags)
+	}
+
+	engine := gre.NewEngine()
+	engine.AddRule(rule)
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("age", 18)
+
+	_, err := engine.Evaluate(almanac)
+	if err == nil || !strings.Contains(err.Error(), "'fact' parameter") {
+		t.Errorf("Expected a setFact params error, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package gorulesengine
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
 	"sync"
 	"time"
 )
@@ -12,7 +15,99 @@ type RuleProvider interface {
 	FetchRules(ctx context.Context) ([]*Rule, error)
 }
 
+// RuleChange describes a rule added, removed or modified between two rule sets.
+type RuleChange struct {
+	Name    string `json:"name"`
+	OldHash string `json:"oldHash,omitempty"` // Content hash before the change (empty for added rules)
+	NewHash string `json:"newHash,omitempty"` // Content hash after the change (empty for removed rules)
+}
+
+// RuleSetDiff describes the changes between two rule sets, matching rules by name.
+type RuleSetDiff struct {
+	Added    []RuleChange `json:"added,omitempty"`
+	Removed  []RuleChange `json:"removed,omitempty"`
+	Modified []RuleChange `json:"modified,omitempty"`
+}
+
+// IsEmpty reports whether the two rule sets are identical.
+func (d RuleSetDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffRules compares two rule sets by rule name and content hash.
+// Changes are sorted by rule name.
+func DiffRules(oldRules, newRules []*Rule) (RuleSetDiff, error) {
+	var diff RuleSetDiff
+
+	oldHashes, err := hashRules(oldRules)
+	if err != nil {
+		return diff, err
+	}
+	newHashes, err := hashRules(newRules)
+	if err != nil {
+		return diff, err
+	}
+
+	for name, newHash := range newHashes {
+		oldHash, exists := oldHashes[name]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, RuleChange{Name: name, NewHash: newHash})
+		case oldHash != newHash:
+			diff.Modified = append(diff.Modified, RuleChange{Name: name, OldHash: oldHash, NewHash: newHash})
+		}
+	}
+	for name, oldHash := range oldHashes {
+		if _, exists := newHashes[name]; !exists {
+			diff.Removed = append(diff.Removed, RuleChange{Name: name, OldHash: oldHash})
+		}
+	}
+
+	for _, changes := range [][]RuleChange{diff.Added, diff.Removed, diff.Modified} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].Name < changes[j].Name
+		})
+	}
+
+	return diff, nil
+}
+
+// hashRules computes the content hash of each rule, keyed by rule name.
+func hashRules(rules []*Rule) (map[string]string, error) {
+	hashes := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		hash, err := rule.Hash()
+		if err != nil {
+			return nil, err
+		}
+		hashes[rule.Name] = hash
+	}
+	return hashes, nil
+}
+
+// hashRuleSet computes a content hash of a whole rule set, independent of rule order.
+func hashRuleSet(rules []*Rule) (string, error) {
+	hashes := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		hash, err := rule.Hash()
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	sum := sha256.New()
+	for _, hash := range hashes {
+		sum.Write([]byte(hash))
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
 // HotReloader manages the periodic reloading of rules from a provider.
+// Candidate rule sets are compiled and validated before being swapped into the
+// engine; an invalid set is reported through OnError and the last-known-good
+// set stays active.
 type HotReloader struct {
 	engine   *Engine
 	provider RuleProvider
@@ -22,7 +117,19 @@ type HotReloader struct {
 	mu       sync.Mutex
 	running  bool
 	onUpdate func([]*Rule)
+	onChange func(RuleSetDiff)
 	onError  func(error)
+
+	// Provider of the candidate rules evaluated in shadow
+	shadowProvider RuleProvider
+
+	// Rule set state, guarded by swapMu
+	swapMu       sync.Mutex
+	current      []*Rule
+	previous     []*Rule
+	hasPrevious  bool
+	rejectedHash string
+	shadowHash   string
 }
 
 // NewHotReloader creates a new hot reloader for an engine.
@@ -35,20 +142,41 @@ func NewHotReloader(engine *Engine, provider RuleProvider, interval time.Duratio
 	}
 }
 
-// OnUpdate sets a callback to be called whenever rules are updated.
+// OnUpdate sets a callback to be called with the new rule set whenever it is
+// swapped (by a reload or a Rollback). Reloads fetching an unchanged or rolled
+// back set do not call it.
 func (h *HotReloader) OnUpdate(callback func([]*Rule)) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.onUpdate = callback
 }
 
+// OnChange sets a callback to be called with the added, removed and modified
+// rules whenever the rule set is swapped (by a reload or a Rollback).
+func (h *HotReloader) OnChange(callback func(RuleSetDiff)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onChange = callback
+}
+
 // OnError sets a callback to be called whenever an error occurs during reload.
+// Rejected rule sets are reported with a ValidationError.
 func (h *HotReloader) OnError(callback func(error)) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.onError = callback
 }
 
+// SetShadowProvider sets a provider publishing candidate rules, applied with
+// Engine.SetShadowRules so that they are evaluated in shadow of the active rules.
+// Each reload also fetches the candidate set; it is validated like the active
+// one, and an invalid set is reported through OnError.
+func (h *HotReloader) SetShadowProvider(provider RuleProvider) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shadowProvider = provider
+}
+
 // Start begins the periodic reloading process.
 func (h *HotReloader) Start(ctx context.Context) {
 	h.mu.Lock()
@@ -67,11 +195,13 @@ func (h *HotReloader) Start(ctx context.Context) {
 
 		// Initial fetch
 		h.reload(ctx)
+		h.reloadShadow(ctx)
 
 		for {
 			select {
 			case <-ticker.C:
 				h.reload(ctx)
+				h.reloadShadow(ctx)
 			case <-h.stopChan:
 				return
 			case <-ctx.Done():
@@ -94,14 +224,92 @@ func (h *HotReloader) Stop() {
 	h.wg.Wait()
 }
 
+// LastKnownGood returns the rule set currently applied by the reloader.
+func (h *HotReloader) LastKnownGood() []*Rule {
+	h.swapMu.Lock()
+	defer h.swapMu.Unlock()
+	h.sync()
+	return h.current
+}
+
+// Rollback restores the rule set that was active before the last swap.
+// If the engine refuses it (e.g. with WithRuleValidation), the current set stays
+// active and an ErrLoader error is returned.
+// The rolled back set is not applied again by later reloads until the provider
+// publishes a different set.
+func (h *HotReloader) Rollback() error {
+	h.swapMu.Lock()
+	h.sync()
+	if !h.hasPrevious {
+		h.swapMu.Unlock()
+		return &RuleEngineError{
+			Type: ErrLoader,
+			Msg:  "no previous rule set to roll back to",
+		}
+	}
+
+	diff, err := DiffRules(h.current, h.previous)
+	var rejectedHash string
+	if err == nil {
+		rejectedHash, err = hashRuleSet(h.current)
+	}
+	swapped := false
+	if err == nil {
+		swapped, err = h.engine.replaceRules(h.previous)
+	}
+	if !swapped {
+		h.swapMu.Unlock()
+		return &RuleEngineError{
+			Type: ErrLoader,
+			Msg:  "failed to roll back rules",
+			Err:  err,
+		}
+	}
+
+	h.rejectedHash = rejectedHash
+	h.current, h.previous, h.hasPrevious = h.previous, nil, false
+	rules := h.current
+	h.swapMu.Unlock()
+
+	// Without rule validation, invalid rules are restored and their diagnostics reported
+	if err != nil {
+		h.notifyError(err)
+	}
+	h.notifyChange(rules, diff)
+	return nil
+}
+
+// sync aligns the reloader state with the engine's rules, which may have been
+// changed outside the reloader (SetRules, AddRule...). Such a set becomes the
+// last-known-good one and the previous set is forgotten, so that a Rollback
+// cannot silently undo it.
+// The caller must hold swapMu.
+func (h *HotReloader) sync() {
+	rules := h.engine.GetRules()
+	if sameRules(h.current, rules) {
+		return
+	}
+	h.current = rules
+	h.previous, h.hasPrevious = nil, false
+}
+
+// sameRules reports whether two rule sets hold the same rules in the same order.
+func sameRules(a, b []*Rule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *HotReloader) reload(ctx context.Context) {
 	rules, err := h.provider.FetchRules(ctx)
 	if err != nil {
-		h.mu.Lock()
-		if h.onError != nil {
-			h.onError(err)
-		}
-		h.mu.Unlock()
+		h.notifyError(err)
 		return
 	}
 
@@ -110,13 +318,129 @@ func (h *HotReloader) reload(ctx context.Context) {
 		return
 	}
 
-	// Hot swap rules in engine
-	// Note: We need a thread-safe way to swap rules in the engine.
-	h.engine.SetRules(rules)
+	// Compile and validate the candidate set before swapping
+	if err := validateRuleSet(rules); err != nil {
+		h.notifyError(err)
+		return
+	}
+
+	h.swapMu.Lock()
+	h.sync()
+
+	diff, err := DiffRules(h.current, rules)
+	var setHash string
+	if err == nil {
+		setHash, err = hashRuleSet(rules)
+	}
+	if err != nil {
+		h.swapMu.Unlock()
+		h.notifyError(&RuleEngineError{
+			Type: ErrLoader,
+			Msg:  "failed to compare rules",
+			Err:  err,
+		})
+		return
+	}
+
+	// Skip unchanged sets and sets that were rolled back
+	if diff.IsEmpty() || setHash == h.rejectedHash {
+		h.swapMu.Unlock()
+		return
+	}
+
+	// Hot swap rules in engine, unless it refuses them
+	if swapped, err := h.engine.replaceRules(rules); !swapped {
+		h.swapMu.Unlock()
+		h.notifyError(&RuleEngineError{
+			Type: ErrLoader,
+			Msg:  "failed to swap rules",
+			Err:  err,
+		})
+		return
+	}
+	h.previous, h.hasPrevious = h.current, true
+	h.current = rules
+	h.rejectedHash = ""
+	h.swapMu.Unlock()
+
+	h.notifyChange(rules, diff)
+}
 
+// reloadShadow fetches the candidate rules of the shadow provider, if any, and
+// sets them as the shadow rules of the engine when they changed.
+func (h *HotReloader) reloadShadow(ctx context.Context) {
 	h.mu.Lock()
+	provider := h.shadowProvider
+	h.mu.Unlock()
+	if provider == nil {
+		return
+	}
+
+	rules, err := provider.FetchRules(ctx)
+	if err != nil {
+		h.notifyError(err)
+		return
+	}
+	if rules == nil {
+		return
+	}
+
+	setHash, err := hashRuleSet(rules)
+	if err != nil {
+		h.notifyError(&RuleEngineError{
+			Type: ErrLoader,
+			Msg:  "failed to compare shadow rules",
+			Err:  err,
+		})
+		return
+	}
+
+	h.swapMu.Lock()
+	if setHash == h.shadowHash {
+		h.swapMu.Unlock()
+		return
+	}
+	err = h.engine.SetShadowRules(rules)
+	if err == nil {
+		h.shadowHash = setHash
+	}
+	h.swapMu.Unlock()
+
+	if err != nil {
+		h.notifyError(err)
+	}
+}
+
+// validateRuleSet compiles and validates the rules of a set and checks them for
+// dependency cycles.
+func validateRuleSet(rules []*Rule) error {
+	var diags Diagnostics
+	for _, rule := range rules {
+		diags = append(diags, validateRuleForEngine(rule)...)
+	}
+	if err := diags.Err(); err != nil {
+		return err
+	}
+	return checkRuleCycles(rules)
+}
+
+// notifyChange invokes the update and change callbacks.
+func (h *HotReloader) notifyChange(rules []*Rule, diff RuleSetDiff) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	if h.onUpdate != nil {
 		h.onUpdate(rules)
 	}
-	h.mu.Unlock()
+	if h.onChange != nil {
+		h.onChange(diff)
+	}
+}
+
+// notifyError invokes the error callback.
+func (h *HotReloader) notifyError(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.onError != nil {
+		h.onError(err)
+	}
 }
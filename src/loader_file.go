@@ -0,0 +1,409 @@
+// FileRuleProvider and DirRuleProvider implement RuleProvider for rules stored in files.
+
+package gorulesengine
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ruleDecoder decodes the content of a rule file into rules.
+// Decoding errors are returned as a *FileParseError locating the problem in the data.
+type ruleDecoder func(data []byte) ([]*Rule, error)
+
+// ruleFileDecoders maps supported file extensions to their decoder.
+var ruleFileDecoders = map[string]ruleDecoder{
+	".json": decodeJSONRules,
+	".yaml": decodeYAMLRules,
+	".yml":  decodeYAMLRules,
+}
+
+// FileRuleProvider implements RuleProvider for rule files matched by paths or glob patterns.
+// Each file holds either a single rule or an array of rules, in JSON or YAML
+// (selected by the .json, .yaml or .yml extension).
+//
+// FetchRules returns nil (no changes) when no matched file changed since the last
+// successful fetch, so a HotReloader only swaps rules when something changed.
+// Files are compared by modification time and size, then by content hash.
+type FileRuleProvider struct {
+	FS         fs.FS    // Filesystem to read from; nil reads from the OS filesystem
+	Patterns   []string // File paths or glob patterns, relative to FS (see fs.Glob)
+	LastUpdate time.Time
+
+	files fileSet
+}
+
+// NewFileRuleProvider creates a provider reading files from the OS filesystem.
+//
+// Example:
+//
+//	provider := gre.NewFileRuleProvider("/etc/rules/*.json")
+func NewFileRuleProvider(patterns ...string) *FileRuleProvider {
+	return &FileRuleProvider{
+		Patterns: patterns,
+	}
+}
+
+// NewFSRuleProvider creates a provider reading files from fsys (e.g. an embed.FS).
+//
+// Example:
+//
+//	//go:embed rules/*.json
+//	var defaultRules embed.FS
+//
+//	provider := gre.NewFSRuleProvider(defaultRules, "rules/*.json")
+func NewFSRuleProvider(fsys fs.FS, patterns ...string) *FileRuleProvider {
+	return &FileRuleProvider{
+		FS:       fsys,
+		Patterns: patterns,
+	}
+}
+
+// FetchRules loads the rules from every matched file, in path order.
+// It returns nil if no file changed since the last successful fetch.
+func (p *FileRuleProvider) FetchRules(ctx context.Context) ([]*Rule, error) {
+	fsys := fsOrOS(p.FS)
+
+	var paths []string
+	for _, pattern := range p.Patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, &RuleEngineError{
+				Type: ErrLoader,
+				Msg:  fmt.Sprintf("invalid pattern '%s'", pattern),
+				Err:  err,
+			}
+		}
+		if len(matches) == 0 && !hasGlobMeta(pattern) {
+			return nil, &RuleEngineError{
+				Type: ErrLoader,
+				Msg:  fmt.Sprintf("rule file '%s' not found", pattern),
+				Err:  fs.ErrNotExist,
+			}
+		}
+		paths = append(paths, matches...)
+	}
+
+	rules, changed, err := p.files.load(ctx, fsys, paths)
+	if err != nil || !changed {
+		return nil, err
+	}
+
+	p.LastUpdate = time.Now()
+	return rules, nil
+}
+
+// DirRuleProvider implements RuleProvider for a directory of rule files.
+// Every file with a supported extension (.json, .yaml, .yml) is loaded, recursively and in
+// path order. Hidden files and directories (starting with ".") are ignored.
+//
+// Like FileRuleProvider, FetchRules returns nil when nothing changed.
+type DirRuleProvider struct {
+	FS         fs.FS  // Filesystem to read from; nil reads from the OS filesystem
+	Dir        string // Directory to load, relative to FS
+	LastUpdate time.Time
+
+	files fileSet
+}
+
+// NewDirRuleProvider creates a provider reading a directory of the OS filesystem.
+func NewDirRuleProvider(dir string) *DirRuleProvider {
+	return &DirRuleProvider{
+		Dir: dir,
+	}
+}
+
+// NewFSDirRuleProvider creates a provider reading a directory of fsys (e.g. an embed.FS).
+func NewFSDirRuleProvider(fsys fs.FS, dir string) *DirRuleProvider {
+	return &DirRuleProvider{
+		FS:  fsys,
+		Dir: dir,
+	}
+}
+
+// FetchRules loads the rules from every rule file of the directory.
+// It returns nil if no file changed since the last successful fetch.
+func (p *DirRuleProvider) FetchRules(ctx context.Context) ([]*Rule, error) {
+	fsys := fsOrOS(p.FS)
+
+	var paths []string
+	err := fs.WalkDir(fsys, p.Dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != p.Dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && ruleFileDecoders[strings.ToLower(path.Ext(name))] != nil {
+			paths = append(paths, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &RuleEngineError{
+			Type: ErrLoader,
+			Msg:  fmt.Sprintf("failed to list rule directory '%s'", p.Dir),
+			Err:  err,
+		}
+	}
+
+	rules, changed, err := p.files.load(ctx, fsys, paths)
+	if err != nil || !changed {
+		return nil, err
+	}
+
+	p.LastUpdate = time.Now()
+	return rules, nil
+}
+
+// fileState records what was last seen of a rule file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	hash    string
+}
+
+// fileSet tracks the state of a set of rule files to detect changes between loads.
+type fileSet struct {
+	mu          sync.Mutex
+	states      map[string]fileState
+	fingerprint string
+}
+
+// load reads and decodes the given files. It reports changed=false (and no rules)
+// when the files are identical to the last successful load.
+// Parse errors of all files are reported together.
+func (s *fileSet) load(ctx context.Context, fsys fs.FS, paths []string) ([]*Rule, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths = uniqueSorted(paths)
+	states := make(map[string]fileState, len(paths))
+	contents := make(map[string][]byte, len(paths))
+
+	fingerprint := sha256.New()
+	for _, name := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			return nil, false, &RuleEngineError{
+				Type: ErrLoader,
+				Msg:  fmt.Sprintf("failed to stat rule file '%s'", name),
+				Err:  err,
+			}
+		}
+
+		state, known := s.states[name]
+		if !known || !state.modTime.Equal(info.ModTime()) || state.size != info.Size() {
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, false, &RuleEngineError{
+					Type: ErrLoader,
+					Msg:  fmt.Sprintf("failed to read rule file '%s'", name),
+					Err:  err,
+				}
+			}
+			sum := sha256.Sum256(data)
+			state = fileState{modTime: info.ModTime(), size: info.Size(), hash: hex.EncodeToString(sum[:])}
+			contents[name] = data
+		}
+		states[name] = state
+		fmt.Fprintf(fingerprint, "%s\x00%s\x00", name, state.hash)
+	}
+
+	s.states = states
+	sum := hex.EncodeToString(fingerprint.Sum(nil))
+	if sum == s.fingerprint {
+		return nil, false, nil
+	}
+
+	var rules []*Rule
+	var errs []error
+	for _, name := range paths {
+		data, read := contents[name]
+		if !read {
+			var err error
+			if data, err = fs.ReadFile(fsys, name); err != nil {
+				return nil, false, &RuleEngineError{
+					Type: ErrLoader,
+					Msg:  fmt.Sprintf("failed to read rule file '%s'", name),
+					Err:  err,
+				}
+			}
+		}
+
+		fileRules, err := decodeRuleFile(name, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	if len(errs) > 0 {
+		return nil, false, &RuleEngineError{
+			Type: ErrLoader,
+			Msg:  fmt.Sprintf("failed to parse %d rule file(s)", len(errs)),
+			Err:  errors.Join(errs...),
+		}
+	}
+
+	s.fingerprint = sum
+	if rules == nil {
+		rules = []*Rule{}
+	}
+	return rules, true, nil
+}
+
+// decodeRuleFile decodes a rule file with the decoder registered for its extension.
+func decodeRuleFile(name string, data []byte) ([]*Rule, error) {
+	decode := ruleFileDecoders[strings.ToLower(path.Ext(name))]
+	if decode == nil {
+		decode = decodeJSONRules
+	}
+
+	rules, err := decode(data)
+	if err != nil {
+		var parseErr *FileParseError
+		if !errors.As(err, &parseErr) {
+			parseErr = &FileParseError{Err: err}
+		}
+		parseErr.File = name
+		return nil, parseErr
+	}
+	return rules, nil
+}
+
+// decodeJSONRules decodes a JSON document holding a single rule or an array of rules.
+// Errors are located at the syntax error, or at the start of the offending rule.
+func decodeJSONRules(data []byte) ([]*Rule, error) {
+	start := skipJSONSpace(data, 0)
+	if start == len(data) {
+		return nil, newJSONParseError(data, int64(start), errors.New("empty rule file"))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if data[start] != '[' {
+		var rule Rule
+		if err := dec.Decode(&rule); err != nil {
+			return nil, newJSONParseError(data, jsonErrorOffset(err, int64(start)), err)
+		}
+		return []*Rule{&rule}, nil
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, newJSONParseError(data, jsonErrorOffset(err, int64(start)), err)
+	}
+
+	var rules []*Rule
+	for i := 0; dec.More(); i++ {
+		offset := skipJSONSpace(data, int(dec.InputOffset()))
+		var rule Rule
+		if err := dec.Decode(&rule); err != nil {
+			return nil, newJSONParseError(data, jsonErrorOffset(err, int64(offset)), fmt.Errorf("rule #%d: %w", i, err))
+		}
+		rules = append(rules, &rule)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, newJSONParseError(data, jsonErrorOffset(err, dec.InputOffset()), err)
+	}
+	return rules, nil
+}
+
+// jsonErrorOffset returns the offset of a JSON syntax error, or fallback for
+// errors that do not carry an absolute offset.
+func jsonErrorOffset(err error, fallback int64) int64 {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset > 0 {
+		// Offset counts the offending byte itself
+		return syntaxErr.Offset - 1
+	}
+	return fallback
+}
+
+// skipJSONSpace returns the index of the first byte at or after i that is not
+// whitespace or a separating comma.
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n', ',':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// newJSONParseError creates a FileParseError with the line and column of offset in data.
+func newJSONParseError(data []byte, offset int64, err error) *FileParseError {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+
+	return &FileParseError{
+		Line:   line,
+		Column: column,
+		Offset: offset,
+		Err:    err,
+	}
+}
+
+// hasGlobMeta reports whether pattern contains glob special characters.
+func hasGlobMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// uniqueSorted sorts paths and removes duplicates (files matched by several patterns).
+func uniqueSorted(paths []string) []string {
+	sort.Strings(paths)
+	out := paths[:0]
+	for i, p := range paths {
+		if i == 0 || p != paths[i-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// fsOrOS returns fsys, or the OS filesystem if fsys is nil.
+func fsOrOS(fsys fs.FS) fs.FS {
+	if fsys == nil {
+		return osFS{}
+	}
+	return fsys
+}
+
+// osFS exposes the OS filesystem as an fs.FS accepting OS paths
+// (absolute or relative to the working directory).
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (osFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) Glob(pattern string) ([]string, error)      { return filepath.Glob(pattern) }
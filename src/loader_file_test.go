@@ -0,0 +1,216 @@
+package gorulesengine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const (
+	singleRuleJSON = `{"name": "single", "conditions": {"all": [{"fact": "age", "operator": "greater_than", "value": 18}]}}`
+	ruleArrayJSON  = `[
+	{"name": "first", "conditions": {"all": [{"fact": "age", "operator": "less_than", "value": 10}]}},
+	{"name": "second", "priority": 5, "conditions": {"any": [{"fact": "country", "operator": "equal", "value": "FR"}]}, "onSuccess": ["notify"]}
+]`
+)
+
+func ruleNames(rules []*Rule) []string {
+	names := make([]string, len(rules))
+	for i, rule := range rules {
+		names[i] = rule.Name
+	}
+	return names
+}
+
+func writeRuleFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestFileRuleProvider(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, filepath.Join(dir, "a.json"), ruleArrayJSON)
+	writeRuleFile(t, filepath.Join(dir, "b.json"), singleRuleJSON)
+	writeRuleFile(t, filepath.Join(dir, "notes.txt"), "not rules")
+
+	provider := NewFileRuleProvider(filepath.Join(dir, "*.json"), filepath.Join(dir, "b.json"))
+	ctx := context.Background()
+
+	rules, err := provider.FetchRules(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := ruleNames(rules); len(names) != 3 || names[0] != "first" || names[1] != "second" || names[2] != "single" {
+		t.Fatalf("Expected rules in file order, got %v", names)
+	}
+	if rules[1].Priority != 5 || len(rules[1].OnSuccess) != 1 {
+		t.Errorf("Expected rule fields to be decoded, got %+v", rules[1])
+	}
+	if provider.LastUpdate.IsZero() {
+		t.Error("Expected LastUpdate to be set")
+	}
+
+	if rules, err := provider.FetchRules(ctx); err != nil || rules != nil {
+		t.Errorf("Expected no changes, got %v, %v", rules, err)
+	}
+
+	// A new modification time with the same content is not a change
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "b.json"), later, later); err != nil {
+		t.Fatalf("Failed to touch file: %v", err)
+	}
+	if rules, err := provider.FetchRules(ctx); err != nil || rules != nil {
+		t.Errorf("Expected touched file not to be a change, got %v, %v", rules, err)
+	}
+
+	writeRuleFile(t, filepath.Join(dir, "b.json"), `[]`)
+	rules, err = provider.FetchRules(ctx)
+	if err != nil || len(rules) != 2 {
+		t.Errorf("Expected changed file to be reloaded, got %v, %v", ruleNames(rules), err)
+	}
+}
+
+func TestFileRuleProvider_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := NewFileRuleProvider(filepath.Join(t.TempDir(), "missing.json")).FetchRules(ctx); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not exist error for a missing file, got %v", err)
+	}
+	if _, err := NewFileRuleProvider("[").FetchRules(ctx); err == nil {
+		t.Error("Expected error for an invalid pattern")
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	fsys := fstest.MapFS{"rules.json": {Data: []byte(singleRuleJSON)}}
+	if _, err := NewFSRuleProvider(fsys, "rules.json").FetchRules(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context error, got %v", err)
+	}
+}
+
+func TestFileRuleProvider_ParseErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"rules/good.json":   {Data: []byte(singleRuleJSON)},
+		"rules/syntax.json": {Data: []byte("[\n  {\"name\": \"r1\",\n    \"priority\": oops}\n]")},
+		"rules/node.json":   {Data: []byte("[\n  {\"name\": \"r1\"},\n  {\"name\": \"r2\", \"conditions\": {\"all\": [42]}}\n]")},
+		"rules/empty.json":  {Data: []byte("  \n")},
+		"rules/type.json":   {Data: []byte(`{"name": "r1", "priority": "high"}`)},
+	}
+	provider := NewFSRuleProvider(fsys, "rules/*.json")
+
+	rules, err := provider.FetchRules(context.Background())
+	if err == nil || rules != nil {
+		t.Fatalf("Expected parse errors, got %v, %v", rules, err)
+	}
+
+	expected := map[string][2]int{
+		"rules/syntax.json": {3, 17},
+		"rules/node.json":   {3, 3},
+		"rules/empty.json":  {2, 1},
+		"rules/type.json":   {1, 1},
+	}
+	joined, ok := errors.Unwrap(err).(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined file errors, got %v", err)
+	}
+	if len(joined.Unwrap()) != len(expected) {
+		t.Fatalf("Expected %d file errors, got %v", len(expected), err)
+	}
+	for _, fileErr := range joined.Unwrap() {
+		var parseErr *FileParseError
+		if !errors.As(fileErr, &parseErr) {
+			t.Fatalf("Expected FileParseError, got %v", fileErr)
+		}
+		pos, ok := expected[parseErr.File]
+		if !ok {
+			t.Errorf("Unexpected file in error: %s", parseErr.File)
+			continue
+		}
+		if parseErr.Line != pos[0] || parseErr.Column != pos[1] {
+			t.Errorf("Expected %s at %d:%d, got %d:%d (%v)", parseErr.File, pos[0], pos[1], parseErr.Line, parseErr.Column, parseErr)
+		}
+	}
+
+	// Fixing the files makes the next fetch succeed
+	fsys["rules/syntax.json"] = &fstest.MapFile{Data: []byte(ruleArrayJSON)}
+	delete(fsys, "rules/node.json")
+	delete(fsys, "rules/empty.json")
+	delete(fsys, "rules/type.json")
+	rules, err = provider.FetchRules(context.Background())
+	if err != nil || len(rules) != 3 {
+		t.Errorf("Expected rules after fix, got %v, %v", ruleNames(rules), err)
+	}
+}
+
+func TestFileParseError(t *testing.T) {
+	err := &FileParseError{File: "a.json", Line: 2, Column: 3, Offset: 10, Err: errors.New("boom")}
+	if err.Error() != "[LOADER_ERROR] file=a.json line=2 column=3 offset=10: boom" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+	if (&FileParseError{File: "a.json"}).Error() != "[LOADER_ERROR] file=a.json" {
+		t.Errorf("Unexpected message without position: %s", (&FileParseError{File: "a.json"}).Error())
+	}
+}
+
+func TestDirRuleProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"rules/b.json":           {Data: []byte(singleRuleJSON)},
+		"rules/nested/a.JSON":    {Data: []byte(ruleArrayJSON)},
+		"rules/README.md":        {Data: []byte("# Rules")},
+		"rules/.git/config.json": {Data: []byte("not rules")},
+		"rules/.draft.json":      {Data: []byte("not rules")},
+		"other/ignored.json":     {Data: []byte("not rules")},
+	}
+	provider := NewFSDirRuleProvider(fsys, "rules")
+
+	rules, err := provider.FetchRules(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := ruleNames(rules); len(names) != 3 || names[0] != "single" || names[1] != "first" {
+		t.Errorf("Expected rules of visible JSON files in path order, got %v", names)
+	}
+
+	if rules, err := provider.FetchRules(context.Background()); err != nil || rules != nil {
+		t.Errorf("Expected no changes, got %v, %v", rules, err)
+	}
+
+	// Removing a file is a change, even if it leaves no rules
+	delete(fsys, "rules/b.json")
+	delete(fsys, "rules/nested/a.JSON")
+	rules, err = provider.FetchRules(context.Background())
+	if err != nil || rules == nil || len(rules) != 0 {
+		t.Errorf("Expected an empty rule set, got %v, %v", rules, err)
+	}
+
+	if _, err := NewDirRuleProvider(filepath.Join(t.TempDir(), "missing")).FetchRules(context.Background()); err == nil {
+		t.Error("Expected error for a missing directory")
+	}
+}
+
+func TestDirRuleProvider_HotReloader(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, filepath.Join(dir, "rules.json"), singleRuleJSON)
+
+	engine := NewEngine()
+	reloader := NewHotReloader(engine, NewDirRuleProvider(dir), time.Hour)
+
+	var changes int
+	reloader.OnChange(func(diff RuleSetDiff) { changes++ })
+
+	reloader.reload(context.Background())
+	reloader.reload(context.Background())
+
+	if changes != 1 {
+		t.Errorf("Expected a single change, got %d", changes)
+	}
+	if rules := engine.GetRules(); len(rules) != 1 || rules[0].Name != "single" {
+		t.Errorf("Expected rules from the directory, got %v", ruleNames(rules))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -30,7 +31,8 @@ func NewHTTPRuleProvider(url string) *HTTPRuleProvider {
 }
 
 // FetchRules fetches rules from the configured URL.
-// It expects a JSON array of rules.
+// It expects a JSON array of rules, or YAML rules when the response
+// Content-Type mentions "yaml" (e.g. application/yaml).
 func (p *HTTPRuleProvider) FetchRules(ctx context.Context) ([]*Rule, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", p.URL, nil)
 	if err != nil {
@@ -75,7 +77,12 @@ func (p *HTTPRuleProvider) FetchRules(ctx context.Context) ([]*Rule, error) {
 	}
 
 	var rules []*Rule
-	if err := json.Unmarshal(body, &rules); err != nil {
+	if strings.Contains(resp.Header.Get("Content-Type"), "yaml") {
+		rules, err = decodeYAMLRules(body)
+	} else {
+		err = json.Unmarshal(body, &rules)
+	}
+	if err != nil {
 		return nil, &RuleEngineError{
 			Type: ErrLoader,
 			Msg:  "failed to unmarshal rules",
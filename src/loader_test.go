@@ -3,6 +3,7 @@ package gorulesengine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -45,6 +46,13 @@ func TestHTTPRuleProvider(t *testing.T) {
 	if fetchedRules[0].Name != "test-rule" {
 		t.Fatalf("Expected rule name 'test-rule', got '%s'", fetchedRules[0].Name)
 	}
+	// Rules marshaled by the server must be readable back with their conditions
+	if len(fetchedRules[0].Conditions.All) != 1 || fetchedRules[0].Conditions.All[0].Condition == nil {
+		t.Fatalf("Expected condition to survive the round-trip, got %+v", fetchedRules[0].Conditions)
+	}
+	if fetchedRules[0].Conditions.All[0].Condition.Fact != "age" {
+		t.Errorf("Expected fact 'age', got '%s'", fetchedRules[0].Conditions.All[0].Condition.Fact)
+	}
 }
 
 func TestHotReloader(t *testing.T) {
@@ -288,3 +296,268 @@ func TestHotReloader_NoUpdate(t *testing.T) {
 		t.Errorf("Expected no updates when provider returns nil, got %d", updateCount)
 	}
 }
+
+// staticRuleProvider returns the rules it holds on each fetch.
+type staticRuleProvider struct {
+	rules []*Rule
+	err   error
+}
+
+func (p *staticRuleProvider) FetchRules(ctx context.Context) ([]*Rule, error) {
+	return p.rules, p.err
+}
+
+func newAgeRule(name string, age int) *Rule {
+	return &Rule{
+		Name:       name,
+		Conditions: ConditionSet{All: []ConditionNode{{Condition: GreaterThan("age", age)}}},
+	}
+}
+
+func TestHotReloader_RejectsInvalidRules(t *testing.T) {
+	good := newAgeRule("good", 18)
+	engine := NewEngine()
+	engine.AddRule(good)
+
+	provider := &staticRuleProvider{rules: []*Rule{
+		newAgeRule("other", 21),
+		{Name: "broken", Conditions: ConditionSet{All: []ConditionNode{{Condition: Regex("email", "[a-z")}}}},
+	}}
+	reloader := NewHotReloader(engine, provider, time.Hour)
+
+	var reloadErr error
+	reloader.OnError(func(err error) { reloadErr = err })
+	reloader.OnUpdate(func(rules []*Rule) { t.Error("Expected no update for an invalid rule set") })
+
+	reloader.reload(context.Background())
+
+	var valErr *ValidationError
+	if !errors.As(reloadErr, &valErr) || valErr.Diagnostics[0].Rule != "broken" {
+		t.Fatalf("Expected ValidationError for rule 'broken', got %v", reloadErr)
+	}
+	if rules := engine.GetRules(); len(rules) != 1 || rules[0] != good {
+		t.Errorf("Expected last-known-good rules to stay active, got %v", rules)
+	}
+	if lkg := reloader.LastKnownGood(); len(lkg) != 1 || lkg[0] != good {
+		t.Errorf("Expected last-known-good set to be the engine rules, got %v", lkg)
+	}
+}
+
+func TestHotReloader_OnChangeAndRollback(t *testing.T) {
+	engine := NewEngine()
+	engine.AddRules(newAgeRule("kept", 18), newAgeRule("modified", 18), newAgeRule("removed", 18))
+	initial := engine.GetRules()
+
+	provider := &staticRuleProvider{rules: []*Rule{
+		newAgeRule("kept", 18), newAgeRule("modified", 21), newAgeRule("added", 18),
+	}}
+	reloader := NewHotReloader(engine, provider, time.Hour)
+
+	if err := reloader.Rollback(); err == nil {
+		t.Error("Expected error when there is no previous rule set")
+	}
+
+	var diffs []RuleSetDiff
+	var updates int
+	reloader.OnChange(func(diff RuleSetDiff) { diffs = append(diffs, diff) })
+	reloader.OnUpdate(func(rules []*Rule) { updates++ })
+
+	reloader.reload(context.Background())
+
+	if len(diffs) != 1 || updates != 1 {
+		t.Fatalf("Expected one change, got %d diffs and %d updates", len(diffs), updates)
+	}
+	diff := diffs[0]
+	if len(diff.Added) != 1 || diff.Added[0].Name != "added" || diff.Added[0].NewHash == "" {
+		t.Errorf("Unexpected added rules: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "removed" || diff.Removed[0].OldHash == "" {
+		t.Errorf("Unexpected removed rules: %+v", diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].Name != "modified" || diff.Modified[0].OldHash == diff.Modified[0].NewHash {
+		t.Errorf("Unexpected modified rules: %+v", diff.Modified)
+	}
+
+	// The same set is not applied twice
+	reloader.reload(context.Background())
+	if len(diffs) != 1 {
+		t.Errorf("Expected no change for an identical rule set, got %d diffs", len(diffs))
+	}
+
+	if err := reloader.Rollback(); err != nil {
+		t.Fatalf("Expected rollback to succeed, got %v", err)
+	}
+	if len(diffs) != 2 || len(diffs[1].Added) != 1 || diffs[1].Added[0].Name != "removed" {
+		t.Errorf("Expected rollback diff to restore 'removed', got %+v", diffs)
+	}
+	rules := engine.GetRules()
+	if len(rules) != len(initial) {
+		t.Fatalf("Expected initial rules to be restored, got %v", rules)
+	}
+	for i := range rules {
+		if rules[i] != initial[i] {
+			t.Errorf("Expected rule %d to be %s, got %s", i, initial[i].Name, rules[i].Name)
+		}
+	}
+
+	// The rolled back set is not re-applied until the provider publishes a new one
+	reloader.reload(context.Background())
+	if len(diffs) != 2 {
+		t.Errorf("Expected rolled back set to be skipped, got %d diffs", len(diffs))
+	}
+	provider.rules = []*Rule{newAgeRule("next", 30)}
+	reloader.reload(context.Background())
+	if len(diffs) != 3 || engine.GetRules()[0].Name != "next" {
+		t.Errorf("Expected new rule set to be applied, got %v", engine.GetRules())
+	}
+}
+
+func TestHotReloader_RollbackRefused(t *testing.T) {
+	// The engine rules predate rule validation and cannot be restored once it is enabled
+	broken := &Rule{Name: "broken", Conditions: ConditionSet{All: []ConditionNode{{Condition: Regex("email", "[a-z")}}}}
+	engine := NewEngine()
+	engine.AddRule(broken)
+
+	provider := &staticRuleProvider{rules: []*Rule{newAgeRule("good", 18)}}
+	reloader := NewHotReloader(engine, provider, time.Hour)
+	reloader.reload(context.Background())
+	reloaded := engine.GetRules()
+
+	WithRuleValidation()(engine)
+	changes := 0
+	reloader.OnChange(func(diff RuleSetDiff) { changes++ })
+
+	err := reloader.Rollback()
+
+	var engineErr *RuleEngineError
+	var valErr *ValidationError
+	if !errors.As(err, &engineErr) || engineErr.Type != ErrLoader || !errors.As(err, &valErr) {
+		t.Fatalf("Expected an ErrLoader error wrapping a ValidationError, got %v", err)
+	}
+	if rules := engine.GetRules(); len(rules) != 1 || rules[0] != reloaded[0] {
+		t.Errorf("Expected the reloaded rules to stay active, got %v", rules)
+	}
+	if lkg := reloader.LastKnownGood(); len(lkg) != 1 || lkg[0] != reloaded[0] {
+		t.Errorf("Expected the last-known-good set to be the active rules, got %v", lkg)
+	}
+	if changes != 0 {
+		t.Errorf("Expected no change for a refused rollback, got %d", changes)
+	}
+}
+
+func TestHotReloader_ExternalRuleChanges(t *testing.T) {
+	engine := NewEngine()
+	engine.AddRule(newAgeRule("initial", 18))
+
+	provider := &staticRuleProvider{rules: []*Rule{newAgeRule("reloaded", 18)}}
+	reloader := NewHotReloader(engine, provider, time.Hour)
+	reloader.reload(context.Background())
+
+	// Rules changed outside the reloader become the last-known-good set
+	manual := newAgeRule("manual", 21)
+	engine.AddRule(manual)
+
+	if lkg := reloader.LastKnownGood(); len(lkg) != 2 || lkg[1] != manual {
+		t.Errorf("Expected the last-known-good set to be the engine rules, got %v", lkg)
+	}
+	if err := reloader.Rollback(); err == nil {
+		t.Error("Expected rollback to be refused after an external change")
+	}
+
+	var diffs []RuleSetDiff
+	reloader.OnChange(func(diff RuleSetDiff) { diffs = append(diffs, diff) })
+	provider.rules = []*Rule{newAgeRule("reloaded", 18), newAgeRule("next", 30)}
+	reloader.reload(context.Background())
+
+	if len(diffs) != 1 || len(diffs[0].Removed) != 1 || diffs[0].Removed[0].Name != "manual" {
+		t.Errorf("Expected the diff to be computed against the engine rules, got %+v", diffs)
+	}
+}
+
+func TestDiffRules(t *testing.T) {
+	a, b := newAgeRule("a", 18), newAgeRule("b", 18)
+
+	diff, err := DiffRules([]*Rule{a, b}, []*Rule{newAgeRule("b", 18), newAgeRule("a", 18)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !diff.IsEmpty() {
+		t.Errorf("Expected identical sets to have an empty diff, got %+v", diff)
+	}
+
+	bad := &Rule{Name: "bad", Conditions: ConditionSet{All: []ConditionNode{{}}}}
+	if _, err := DiffRules([]*Rule{bad}, nil); err == nil {
+		t.Error("Expected error for a rule that cannot be hashed")
+	}
+	if _, err := DiffRules(nil, []*Rule{bad}); err == nil {
+		t.Error("Expected error for a rule that cannot be hashed")
+	}
+}
+
+func TestHTTPRuleProvider_YAML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write([]byte("- name: r1\n  conditions:\n    all:\n      - {fact: age, operator: equal, value: 18}\n  onSuccess: [notify]\n"))
+	}))
+	defer server.Close()
+
+	rules, err := NewHTTPRuleProvider(server.URL).FetchRules(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules) != 1 || rules[0].Conditions.All[0].Condition.Value != 18.0 || rules[0].OnSuccess[0].Name != "notify" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+}
+
+func TestHotReloader_RejectsRuleCycles(t *testing.T) {
+	engine := NewEngine()
+	provider := &staticRuleProvider{rules: []*Rule{
+		{Name: "a", Conditions: ConditionSet{All: []ConditionNode{{Condition: &Condition{Rule: "b", Operator: OperatorEqual, Value: true}}}}},
+		{Name: "b", Conditions: ConditionSet{All: []ConditionNode{{Condition: &Condition{Rule: "a", Operator: OperatorEqual, Value: true}}}}},
+	}}
+	reloader := NewHotReloader(engine, provider, time.Hour)
+
+	var reloadErr error
+	reloader.OnError(func(err error) { reloadErr = err })
+	reloader.reload(context.Background())
+
+	var valErr *ValidationError
+	if !errors.As(reloadErr, &valErr) || valErr.Diagnostics[0].Code != DiagnosticRuleCycle {
+		t.Errorf("Expected rule cycle error, got %v", reloadErr)
+	}
+	if len(engine.GetRules()) != 0 {
+		t.Errorf("Expected rules not to be swapped, got %d rules", len(engine.GetRules()))
+	}
+}
+
+func TestHotReloader_ShadowProvider(t *testing.T) {
+	engine := NewEngine()
+	engine.AddRule(newAgeRule("active", 18))
+
+	shadow := &staticRuleProvider{rules: []*Rule{newAgeRule("candidate", 21)}}
+	reloader := NewHotReloader(engine, &staticRuleProvider{}, time.Hour)
+	reloader.SetShadowProvider(shadow)
+
+	var reloadErr error
+	reloader.OnError(func(err error) { reloadErr = err })
+
+	reloader.reloadShadow(context.Background())
+	if rules := engine.ShadowRules(); len(rules) != 1 || rules[0].Name != "candidate" {
+		t.Fatalf("Expected the candidate rules to be set in shadow, got %v", rules)
+	}
+	if rules := engine.GetRules(); len(rules) != 1 || rules[0].Name != "active" {
+		t.Errorf("Expected the active rules to be kept, got %v", rules)
+	}
+
+	shadow.rules = []*Rule{{Name: "broken", Conditions: ConditionSet{All: []ConditionNode{{Condition: Regex("email", "[a-z")}}}}}
+	reloader.reloadShadow(context.Background())
+
+	var valErr *ValidationError
+	if !errors.As(reloadErr, &valErr) {
+		t.Fatalf("Expected a ValidationError, got %v", reloadErr)
+	}
+	if rules := engine.ShadowRules(); len(rules) != 1 || rules[0].Name != "candidate" {
+		t.Errorf("Expected the previous candidate rules to be kept, got %v", rules)
+	}
+}
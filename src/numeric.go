@@ -0,0 +1,171 @@
+package gorulesengine
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// NumericMode defines how equality-based operators (equal, not_equal, in, not_in,
+// contains, not_contains) compare numbers of different Go types.
+type NumericMode string
+
+const (
+	// NumericLenient compares numbers by value across int, uint, float and
+	// json.Number types, so an int fact of 18 equals a JSON rule value of 18
+	// (decoded as float64). Integers are compared exactly, without rounding
+	// through float64 (default).
+	NumericLenient NumericMode = "lenient"
+	// NumericStrict only considers values of the same Go type equal.
+	NumericStrict NumericMode = "strict"
+)
+
+// NumericModeOperator can be implemented by an Operator comparing values for
+// equality, to compare numbers according to the NumericMode of the engine.
+// Operators that do not implement it are called through Evaluate.
+type NumericModeOperator interface {
+	EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error)
+}
+
+// WithNumericMode sets how equality-based operators compare numbers of different
+// types (NumericLenient by default). An unknown mode is treated as NumericLenient.
+func WithNumericMode(mode NumericMode) EngineOption {
+	return func(e *Engine) {
+		if e == nil {
+			return
+		}
+		if e.options == nil {
+			e.options = make(map[string]interface{})
+		}
+		e.options[EngineOptionKeyNumericMode] = mode
+	}
+}
+
+// numericModeOf returns the numeric mode set in the engine options.
+func numericModeOf(options map[string]interface{}) NumericMode {
+	if mode, _ := options[EngineOptionKeyNumericMode].(NumericMode); mode == NumericStrict {
+		return NumericStrict
+	}
+	return NumericLenient
+}
+
+// numericModeKey is the context key of the numeric mode of a run.
+type numericModeKey struct{}
+
+// withNumericMode returns a context carrying the numeric mode of a run.
+func withNumericMode(ctx context.Context, mode NumericMode) context.Context {
+	return context.WithValue(ctx, numericModeKey{}, mode)
+}
+
+// numericModeFromContext returns the numeric mode carried by ctx, or NumericLenient.
+func numericModeFromContext(ctx context.Context) NumericMode {
+	if mode, _ := ctx.Value(numericModeKey{}).(NumericMode); mode == NumericStrict {
+		return NumericStrict
+	}
+	return NumericLenient
+}
+
+// valuesEqual reports whether two non-nil values are equal. With NumericLenient,
+// numbers are compared by value whatever their types; other values must have
+// the same type and be deeply equal.
+func valuesEqual(a, b interface{}, mode NumericMode) bool {
+	if mode != NumericStrict {
+		if x, ok := toNumber(a); ok {
+			if y, ok := toNumber(b); ok {
+				return x.equal(y)
+			}
+		}
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// numberKind tells which field of a number holds its value.
+type numberKind int
+
+const (
+	numberInt numberKind = iota
+	numberUint
+	numberFloat
+)
+
+// number is a numeric value normalized to int64, uint64 or float64, so that
+// integers are compared without losing precision.
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// toNumber normalizes any numeric type, including json.Number, to a number.
+func toNumber(value interface{}) (number, bool) {
+	switch v := value.(type) {
+	case int:
+		return number{kind: numberInt, i: int64(v)}, true
+	case int64:
+		return number{kind: numberInt, i: v}, true
+	case int32:
+		return number{kind: numberInt, i: int64(v)}, true
+	case int16:
+		return number{kind: numberInt, i: int64(v)}, true
+	case int8:
+		return number{kind: numberInt, i: int64(v)}, true
+	case uint:
+		return number{kind: numberUint, u: uint64(v)}, true
+	case uint64:
+		return number{kind: numberUint, u: v}, true
+	case uint32:
+		return number{kind: numberUint, u: uint64(v)}, true
+	case uint16:
+		return number{kind: numberUint, u: uint64(v)}, true
+	case uint8:
+		return number{kind: numberUint, u: uint64(v)}, true
+	case float64:
+		return number{kind: numberFloat, f: v}, true
+	case float32:
+		return number{kind: numberFloat, f: float64(v)}, true
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return number{kind: numberInt, i: i}, true
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return number{kind: numberUint, u: u}, true
+		}
+		if f, err := v.Float64(); err == nil {
+			return number{kind: numberFloat, f: f}, true
+		}
+		return number{}, false
+	default:
+		return number{}, false
+	}
+}
+
+// equal reports whether two numbers have the same value.
+func (n number) equal(o number) bool {
+	if n.kind > o.kind {
+		n, o = o, n
+	}
+
+	switch {
+	case n.kind == numberInt && o.kind == numberInt:
+		return n.i == o.i
+	case n.kind == numberUint && o.kind == numberUint:
+		return n.u == o.u
+	case n.kind == numberFloat:
+		return n.f == o.f
+	case n.kind == numberInt && o.kind == numberUint:
+		return n.i >= 0 && uint64(n.i) == o.u
+	case n.kind == numberInt:
+		// o is a float: it must be integral and within the int64 range
+		return o.f == math.Trunc(o.f) && o.f >= math.MinInt64 && o.f < math.MaxInt64 && int64(o.f) == n.i
+	default:
+		// n is a uint and o a float: o must be integral and within the uint64 range
+		return o.f == math.Trunc(o.f) && o.f >= 0 && o.f < math.MaxUint64 && uint64(o.f) == n.u
+	}
+}
@@ -0,0 +1,150 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// numericKinds holds 18 as every numeric type handled by the operators.
+var numericKinds = []interface{}{
+	int(18), int64(18), int32(18), int16(18), int8(18),
+	uint(18), uint64(18), uint32(18), uint16(18), uint8(18),
+	float64(18), float32(18), json.Number("18"), json.Number("18.0"),
+}
+
+func TestEqualOperator_NumericKinds(t *testing.T) {
+	operator := &gre.EqualOperator{}
+
+	for _, a := range numericKinds {
+		for _, b := range numericKinds {
+			name := fmt.Sprintf("%T(%v)=%T(%v)", a, a, b, b)
+
+			if equal, err := operator.Evaluate(a, b); err != nil || !equal {
+				t.Errorf("%s: expected lenient equality, got %v (%v)", name, equal, err)
+			}
+
+			strict, err := operator.EvaluateMode(a, b, gre.NumericStrict)
+			if err != nil || strict != (fmt.Sprintf("%T%v", a, a) == fmt.Sprintf("%T%v", b, b)) {
+				t.Errorf("%s: unexpected strict equality %v (%v)", name, strict, err)
+			}
+		}
+		if equal, _ := operator.Evaluate(a, 18.5); equal {
+			t.Errorf("%T(%v): expected not to equal 18.5", a, a)
+		}
+		if equal, _ := operator.Evaluate(a, "18"); equal {
+			t.Errorf("%T(%v): expected not to equal the string \"18\"", a, a)
+		}
+	}
+}
+
+func TestEqualOperator_NumericPrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     interface{}
+		expected bool
+	}{
+		{"int64 above 2^53", int64(1<<53 + 1), float64(1 << 53), false},
+		{"int64 exactly 2^53", int64(1 << 53), float64(1 << 53), true},
+		{"int64 max vs float 2^63", int64(math.MaxInt64), float64(math.MaxInt64), false},
+		{"int64 min vs float -2^63", int64(math.MinInt64), float64(math.MinInt64), true},
+		{"uint64 max vs int64 -1", uint64(math.MaxUint64), int64(-1), false},
+		{"uint64 max vs float 2^64", uint64(math.MaxUint64), float64(math.MaxUint64), false},
+		{"uint64 above int64 max", uint64(math.MaxInt64) + 1, json.Number("9223372036854775808"), true},
+		{"int64 max as json.Number", int64(math.MaxInt64), json.Number("9223372036854775807"), true},
+		{"large json.Numbers", json.Number("9007199254740993"), json.Number("9007199254740992"), false},
+		{"negative int vs uint", int(-18), uint(18), false},
+		{"fractional float vs int", 18.5, 18, false},
+		{"NaN", math.NaN(), math.NaN(), false},
+		{"invalid json.Number", json.Number("abc"), 0, false},
+	}
+
+	operator := &gre.EqualOperator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, err := operator.Evaluate(tt.a, tt.b)
+			if err != nil || equal != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, equal, err)
+			}
+			reversed, _ := operator.Evaluate(tt.b, tt.a)
+			if reversed != equal {
+				t.Errorf("Expected a symmetric result, got %v and %v", equal, reversed)
+			}
+		})
+	}
+}
+
+func TestCollectionOperators_NumericKinds(t *testing.T) {
+	jsonArray := []interface{}{float64(18), float64(21)}
+
+	for _, value := range numericKinds {
+		name := fmt.Sprintf("%T(%v)", value, value)
+
+		if in, err := (&gre.InOperator{}).Evaluate(value, jsonArray); err != nil || !in {
+			t.Errorf("%s: expected in, got %v (%v)", name, in, err)
+		}
+		if notIn, err := (&gre.NotInOperator{}).Evaluate(value, jsonArray); err != nil || notIn {
+			t.Errorf("%s: expected not not_in, got %v (%v)", name, notIn, err)
+		}
+		if contains, err := (&gre.ContainsOperator{}).Evaluate(jsonArray, value); err != nil || !contains {
+			t.Errorf("%s: expected contains, got %v (%v)", name, contains, err)
+		}
+		if notContains, err := (&gre.NotContainsOperator{}).Evaluate([]int{18}, value); err != nil || notContains {
+			t.Errorf("%s: expected not not_contains, got %v (%v)", name, notContains, err)
+		}
+		if notEqual, err := (&gre.NotEqualOperator{}).Evaluate(value, 18); err != nil || notEqual {
+			t.Errorf("%s: expected not not_equal, got %v (%v)", name, notEqual, err)
+		}
+	}
+
+	if in, _ := (&gre.InOperator{}).EvaluateMode(18, jsonArray, gre.NumericStrict); in {
+		t.Error("Expected an int not to be in a float64 array in strict mode")
+	}
+	if contains, _ := (&gre.ContainsOperator{}).EvaluateMode(jsonArray, 18, gre.NumericStrict); contains {
+		t.Error("Expected a float64 array not to contain an int in strict mode")
+	}
+}
+
+func TestEngine_NumericMode(t *testing.T) {
+	// Rule values loaded from JSON are float64
+	var rule gre.Rule
+	data := `{"name": "adult", "conditions": {"all": [
+		{"fact": "age", "operator": "equal", "value": 18},
+		{"fact": "country", "operator": "in", "value": [33, 44]}
+	]}}`
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     []gre.EngineOption
+		expected bool
+	}{
+		{"default", nil, true},
+		{"lenient", []gre.EngineOption{gre.WithNumericMode(gre.NumericLenient)}, true},
+		{"strict", []gre.EngineOption{gre.WithNumericMode(gre.NumericStrict)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gre.NewEngine(tt.opts...)
+			engine.AddRule(&rule)
+
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("age", 18)
+			almanac.AddFact("country", int64(33))
+
+			run, err := engine.Evaluate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := run.ReduceResults()["adult"]; got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
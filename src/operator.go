@@ -1,10 +1,13 @@
 package gorulesengine
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // Operator defines the interface for all comparison operators.
@@ -47,21 +50,77 @@ type NotContainsOperator struct{}
 // RegexOperator checks if factValue matches the regex pattern in compareValue.
 type RegexOperator struct{}
 
+// BetweenOperator checks if factValue is within the compareValue range.
+type BetweenOperator struct{}
+
+// NotBetweenOperator checks if factValue is outside the compareValue range.
+type NotBetweenOperator struct{}
+
+// StartsWithOperator checks if factValue starts with compareValue.
+type StartsWithOperator struct{}
+
+// EndsWithOperator checks if factValue ends with compareValue.
+type EndsWithOperator struct{}
+
+// EqualIgnoreCaseOperator checks if factValue equals compareValue, ignoring case.
+type EqualIgnoreCaseOperator struct{}
+
+// InIgnoreCaseOperator checks if factValue is contained in the compareValue array, ignoring case.
+type InIgnoreCaseOperator struct{}
+
+// LengthEqualOperator checks if len(factValue) == compareValue.
+type LengthEqualOperator struct{}
+
+// LengthGreaterThanOperator checks if len(factValue) > compareValue.
+type LengthGreaterThanOperator struct{}
+
+// LengthGreaterThanInclusiveOperator checks if len(factValue) >= compareValue.
+type LengthGreaterThanInclusiveOperator struct{}
+
+// LengthLessThanOperator checks if len(factValue) < compareValue.
+type LengthLessThanOperator struct{}
+
+// LengthLessThanInclusiveOperator checks if len(factValue) <= compareValue.
+type LengthLessThanInclusiveOperator struct{}
+
 var operatorRegistry map[OperatorType]Operator
 
 func init() {
 	operatorRegistry = map[OperatorType]Operator{
-		OperatorEqual:                &EqualOperator{},
-		OperatorNotEqual:             &NotEqualOperator{},
-		OperatorLessThan:             &LessThanOperator{},
-		OperatorLessThanInclusive:    &LessThanInclusiveOperator{},
-		OperatorGreaterThan:          &GreaterThanOperator{},
-		OperatorGreaterThanInclusive: &GreaterThanInclusiveOperator{},
-		OperatorIn:                   &InOperator{},
-		OperatorNotIn:                &NotInOperator{},
-		OperatorContains:             &ContainsOperator{},
-		OperatorNotContains:          &NotContainsOperator{},
-		OperatorRegex:                &RegexOperator{},
+		OperatorEqual:                      &EqualOperator{},
+		OperatorNotEqual:                   &NotEqualOperator{},
+		OperatorLessThan:                   &LessThanOperator{},
+		OperatorLessThanInclusive:          &LessThanInclusiveOperator{},
+		OperatorGreaterThan:                &GreaterThanOperator{},
+		OperatorGreaterThanInclusive:       &GreaterThanInclusiveOperator{},
+		OperatorIn:                         &InOperator{},
+		OperatorNotIn:                      &NotInOperator{},
+		OperatorContains:                   &ContainsOperator{},
+		OperatorNotContains:                &NotContainsOperator{},
+		OperatorRegex:                      &RegexOperator{},
+		OperatorBetween:                    &BetweenOperator{},
+		OperatorNotBetween:                 &NotBetweenOperator{},
+		OperatorStartsWith:                 &StartsWithOperator{},
+		OperatorEndsWith:                   &EndsWithOperator{},
+		OperatorEqualIgnoreCase:            &EqualIgnoreCaseOperator{},
+		OperatorInIgnoreCase:               &InIgnoreCaseOperator{},
+		OperatorLengthEqual:                &LengthEqualOperator{},
+		OperatorLengthGreaterThan:          &LengthGreaterThanOperator{},
+		OperatorLengthLessThan:             &LengthLessThanOperator{},
+		OperatorLengthGreaterThanInclusive: &LengthGreaterThanInclusiveOperator{},
+		OperatorLengthLessThanInclusive:    &LengthLessThanInclusiveOperator{},
+		OperatorBefore:                     &BeforeOperator{},
+		OperatorAfter:                      &AfterOperator{},
+		OperatorBetweenDates:               &BetweenDatesOperator{},
+		OperatorWithinLast:                 &WithinLastOperator{},
+		OperatorOlderThan:                  &OlderThanOperator{},
+		OperatorDayOfWeekIn:                &DayOfWeekInOperator{},
+		OperatorTimeOfDayBetween:           &TimeOfDayBetweenOperator{},
+		OperatorExists:                     &ExistsOperator{},
+		OperatorNotExists:                  &NotExistsOperator{},
+		OperatorIsNull:                     &IsNullOperator{},
+		OperatorIsEmpty:                    &IsEmptyOperator{},
+		OperatorIsNotEmpty:                 &IsNotEmptyOperator{},
 	}
 }
 
@@ -124,6 +183,9 @@ func toFloat64(value interface{}) (float64, bool) {
 		return float64(v), true
 	case uint8:
 		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
 	default:
 		// Fallback with reflection for exotic types
 		return 0, false
@@ -131,8 +193,14 @@ func toFloat64(value interface{}) (float64, bool) {
 }
 
 // Evaluate checks if two values are equal using deep equality comparison.
-// Returns false if the values have different types or if either value is nil.
+// Numbers are compared by value whatever their types (see NumericLenient);
+// other values of different types are never equal. Either value being nil is an error.
 func (o *EqualOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *EqualOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
 	if factValue == nil || compareValue == nil {
 		return false, &OperatorError{
 			Operator:     OperatorEqual,
@@ -142,17 +210,18 @@ func (o *EqualOperator) Evaluate(factValue interface{}, compareValue interface{}
 		}
 	}
 
-	if reflect.TypeOf(factValue) != reflect.TypeOf(compareValue) {
-		return false, nil
-	}
-
-	return reflect.DeepEqual(factValue, compareValue), nil
+	return valuesEqual(factValue, compareValue, mode), nil
 }
 
 // Evaluate checks if two values are not equal.
 // Returns the inverse of the EqualOperator result.
 func (o *NotEqualOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
-	equal, err := (&EqualOperator{}).Evaluate(factValue, compareValue)
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *NotEqualOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
+	equal, err := (&EqualOperator{}).EvaluateMode(factValue, compareValue, mode)
 	if err != nil {
 		return false, &OperatorError{
 			Operator:     OperatorNotEqual,
@@ -228,9 +297,46 @@ func (o *GreaterThanInclusiveOperator) Evaluate(factValue interface{}, compareVa
 	return fv >= cv, nil
 }
 
+// ValidateValue checks that compareValue is numeric.
+func (o *LessThanOperator) ValidateValue(compareValue interface{}) error {
+	return validateNumericValue(OperatorLessThan, compareValue)
+}
+
+// ValidateValue checks that compareValue is numeric.
+func (o *LessThanInclusiveOperator) ValidateValue(compareValue interface{}) error {
+	return validateNumericValue(OperatorLessThanInclusive, compareValue)
+}
+
+// ValidateValue checks that compareValue is numeric.
+func (o *GreaterThanOperator) ValidateValue(compareValue interface{}) error {
+	return validateNumericValue(OperatorGreaterThan, compareValue)
+}
+
+// ValidateValue checks that compareValue is numeric.
+func (o *GreaterThanInclusiveOperator) ValidateValue(compareValue interface{}) error {
+	return validateNumericValue(OperatorGreaterThanInclusive, compareValue)
+}
+
+// validateNumericValue reports an OperatorError if compareValue is not numeric.
+func validateNumericValue(op OperatorType, compareValue interface{}) error {
+	if _, ok := toFloat64(compareValue); !ok {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a numeric value", op),
+		}
+	}
+	return nil
+}
+
 // Evaluate checks if factValue is contained in the compareValue array.
-// compareValue must be a slice or array.
+// compareValue must be a slice or array. Elements are compared as EqualOperator does.
 func (o *InOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *InOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
 	// Use reflection to handle any slice type
 	rv := reflect.ValueOf(compareValue)
 
@@ -247,7 +353,7 @@ func (o *InOperator) Evaluate(factValue interface{}, compareValue interface{}) (
 	// Iterate over slice elements
 	for i := 0; i < rv.Len(); i++ {
 		elem := rv.Index(i).Interface()
-		equal, err := (&EqualOperator{}).Evaluate(factValue, elem)
+		equal, err := (&EqualOperator{}).EvaluateMode(factValue, elem, mode)
 		if err != nil {
 			return false, err
 		}
@@ -259,10 +365,38 @@ func (o *InOperator) Evaluate(factValue interface{}, compareValue interface{}) (
 	return false, nil
 }
 
+// ValidateValue checks that compareValue is an array or slice.
+func (o *InOperator) ValidateValue(compareValue interface{}) error {
+	return validateArrayValue(OperatorIn, compareValue)
+}
+
+// ValidateValue checks that compareValue is an array or slice.
+func (o *NotInOperator) ValidateValue(compareValue interface{}) error {
+	return validateArrayValue(OperatorNotIn, compareValue)
+}
+
+// validateArrayValue reports an OperatorError if compareValue is not an array or slice.
+func validateArrayValue(op OperatorType, compareValue interface{}) error {
+	kind := reflect.ValueOf(compareValue).Kind()
+	if kind != reflect.Slice && kind != reflect.Array {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires an array or slice as compareValue", op),
+		}
+	}
+	return nil
+}
+
 // Evaluate checks if factValue is not contained in the compareValue array.
 // Returns the inverse of the InOperator result.
 func (o *NotInOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
-	in, err := (&InOperator{}).Evaluate(factValue, compareValue)
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *NotInOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
+	in, err := (&InOperator{}).EvaluateMode(factValue, compareValue, mode)
 	if err != nil {
 		return false, &OperatorError{
 			Operator:     OperatorNotIn,
@@ -275,8 +409,14 @@ func (o *NotInOperator) Evaluate(factValue interface{}, compareValue interface{}
 }
 
 // Evaluate checks if factValue contains compareValue.
-// For strings, checks substring containment. For arrays/slices, checks element presence.
+// For strings, checks substring containment. For arrays/slices, checks element
+// presence, comparing elements as EqualOperator does.
 func (o *ContainsOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *ContainsOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
 	// Use reflection to handle any slice or string type
 	rv := reflect.ValueOf(factValue)
 
@@ -285,7 +425,7 @@ func (o *ContainsOperator) Evaluate(factValue interface{}, compareValue interfac
 		// Iterate over slice elements
 		for i := 0; i < rv.Len(); i++ {
 			elem := rv.Index(i).Interface()
-			equal, err := (&EqualOperator{}).Evaluate(elem, compareValue)
+			equal, err := (&EqualOperator{}).EvaluateMode(elem, compareValue, mode)
 			if err != nil {
 				return false, &OperatorError{
 					Operator:     OperatorContains,
@@ -323,13 +463,38 @@ func (o *ContainsOperator) Evaluate(factValue interface{}, compareValue interfac
 // Evaluate checks if factValue does not contain compareValue.
 // Returns the inverse of the ContainsOperator result.
 func (o *NotContainsOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
-	contains, err := (&ContainsOperator{}).Evaluate(factValue, compareValue)
+	return o.EvaluateMode(factValue, compareValue, NumericLenient)
+}
+
+// EvaluateMode is like Evaluate but compares numbers according to mode.
+func (o *NotContainsOperator) EvaluateMode(factValue interface{}, compareValue interface{}, mode NumericMode) (bool, error) {
+	contains, err := (&ContainsOperator{}).EvaluateMode(factValue, compareValue, mode)
 	if err != nil {
 		return false, err
 	}
 	return !contains, nil
 }
 
+// ValidateValue checks that compareValue is a string holding a valid regex pattern.
+func (o *RegexOperator) ValidateValue(compareValue interface{}) error {
+	pattern, ok := compareValue.(string)
+	if !ok {
+		return &OperatorError{
+			Operator:     OperatorRegex,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("regex operator requires a string pattern"),
+		}
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return &OperatorError{
+			Operator:     OperatorRegex,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("invalid regex pattern: %w", err),
+		}
+	}
+	return nil
+}
+
 // Evaluate checks if factValue matches the regex pattern in compareValue.
 // Both values must be strings.
 // Returns an error if regex evaluation fails.
@@ -355,3 +520,355 @@ func (o *RegexOperator) Evaluate(factValue interface{}, compareValue interface{}
 	}
 	return matched, nil
 }
+
+// numericRange is the range of the between and not_between operators.
+type numericRange struct {
+	min, max                   float64
+	minExclusive, maxExclusive bool
+}
+
+// contains reports whether v is within the range.
+func (r numericRange) contains(v float64) bool {
+	aboveMin := v > r.min || (!r.minExclusive && v == r.min)
+	belowMax := v < r.max || (!r.maxExclusive && v == r.max)
+	return aboveMin && belowMax
+}
+
+// parseNumericRange parses a [min, max] array (inclusive bounds) or a
+// {"min": ..., "max": ..., "bounds": "[)"} object, where bounds is one of
+// "[]" (default), "()", "[)" and "(]".
+func parseNumericRange(value interface{}) (numericRange, error) {
+	var r numericRange
+	var lower, upper interface{}
+
+	if m, ok := value.(map[string]interface{}); ok {
+		lower, upper = m["min"], m["max"]
+		if bounds, ok := m["bounds"]; ok {
+			switch bounds {
+			case "[]":
+			case "()":
+				r.minExclusive, r.maxExclusive = true, true
+			case "[)":
+				r.maxExclusive = true
+			case "(]":
+				r.minExclusive = true
+			default:
+				return r, fmt.Errorf("bounds must be one of \"[]\", \"()\", \"[)\" and \"(]\", got %v", bounds)
+			}
+		}
+	} else {
+		rv := reflect.ValueOf(value)
+		if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() != 2 {
+			return r, fmt.Errorf("expected [min, max] or {\"min\", \"max\", \"bounds\"}")
+		}
+		lower, upper = rv.Index(0).Interface(), rv.Index(1).Interface()
+	}
+
+	var ok bool
+	if r.min, ok = toFloat64(lower); !ok {
+		return r, fmt.Errorf("min %v is not numeric", lower)
+	}
+	if r.max, ok = toFloat64(upper); !ok {
+		return r, fmt.Errorf("max %v is not numeric", upper)
+	}
+	if r.min > r.max {
+		return r, fmt.Errorf("min %v is greater than max %v", lower, upper)
+	}
+	return r, nil
+}
+
+// Evaluate checks if factValue is within the compareValue range: a [min, max]
+// array (inclusive) or a {"min", "max", "bounds"} object, bounds being one of
+// "[]" (default), "()", "[)" and "(]". factValue must be numeric.
+func (o *BetweenOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateBetween(OperatorBetween, factValue, compareValue)
+}
+
+// ValidateValue checks that compareValue is a valid numeric range.
+func (o *BetweenOperator) ValidateValue(compareValue interface{}) error {
+	return validateRangeValue(OperatorBetween, compareValue)
+}
+
+// Evaluate checks if factValue is outside the compareValue range.
+// Returns the inverse of the BetweenOperator result.
+func (o *NotBetweenOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	between, err := evaluateBetween(OperatorNotBetween, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return !between, nil
+}
+
+// ValidateValue checks that compareValue is a valid numeric range.
+func (o *NotBetweenOperator) ValidateValue(compareValue interface{}) error {
+	return validateRangeValue(OperatorNotBetween, compareValue)
+}
+
+// evaluateBetween checks if factValue is within the compareValue range, reporting errors for op.
+func evaluateBetween(op OperatorType, factValue interface{}, compareValue interface{}) (bool, error) {
+	fv, ok := toFloat64(factValue)
+	if !ok {
+		return false, &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a numeric value", op),
+		}
+	}
+	r, err := parseNumericRange(compareValue)
+	if err != nil {
+		return false, &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("invalid %s range: %w", op, err),
+		}
+	}
+	return r.contains(fv), nil
+}
+
+// validateRangeValue reports an OperatorError if compareValue is not a valid numeric range.
+func validateRangeValue(op OperatorType, compareValue interface{}) error {
+	if _, err := parseNumericRange(compareValue); err != nil {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("invalid %s range: %w", op, err),
+		}
+	}
+	return nil
+}
+
+// stringOperands returns factValue and compareValue as strings, or an OperatorError for op.
+func stringOperands(op OperatorType, factValue interface{}, compareValue interface{}) (string, string, error) {
+	fv, ok1 := factValue.(string)
+	cv, ok2 := compareValue.(string)
+	if !ok1 || !ok2 {
+		return "", "", &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires string values", op),
+		}
+	}
+	return fv, cv, nil
+}
+
+// validateStringValue reports an OperatorError if compareValue is not a string.
+func validateStringValue(op OperatorType, compareValue interface{}) error {
+	if _, ok := compareValue.(string); !ok {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a string value", op),
+		}
+	}
+	return nil
+}
+
+// Evaluate checks if factValue starts with compareValue. Both values must be strings.
+func (o *StartsWithOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	fv, cv, err := stringOperands(OperatorStartsWith, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(fv, cv), nil
+}
+
+// ValidateValue checks that compareValue is a string.
+func (o *StartsWithOperator) ValidateValue(compareValue interface{}) error {
+	return validateStringValue(OperatorStartsWith, compareValue)
+}
+
+// Evaluate checks if factValue ends with compareValue. Both values must be strings.
+func (o *EndsWithOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	fv, cv, err := stringOperands(OperatorEndsWith, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasSuffix(fv, cv), nil
+}
+
+// ValidateValue checks that compareValue is a string.
+func (o *EndsWithOperator) ValidateValue(compareValue interface{}) error {
+	return validateStringValue(OperatorEndsWith, compareValue)
+}
+
+// Evaluate checks if factValue equals compareValue under Unicode case folding.
+// Both values must be strings.
+func (o *EqualIgnoreCaseOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	fv, cv, err := stringOperands(OperatorEqualIgnoreCase, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(fv, cv), nil
+}
+
+// ValidateValue checks that compareValue is a string.
+func (o *EqualIgnoreCaseOperator) ValidateValue(compareValue interface{}) error {
+	return validateStringValue(OperatorEqualIgnoreCase, compareValue)
+}
+
+// Evaluate checks if factValue equals one of the strings of the compareValue array
+// under Unicode case folding. factValue must be a string, and compareValue a slice
+// or array of strings.
+func (o *InIgnoreCaseOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	fv, ok := factValue.(string)
+	if !ok {
+		return false, &OperatorError{
+			Operator:     OperatorInIgnoreCase,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("in_ignore_case operator requires a string value"),
+		}
+	}
+	values, err := stringElements(compareValue)
+	if err != nil {
+		return false, &OperatorError{
+			Operator:     OperatorInIgnoreCase,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          err,
+		}
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(fv, v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ValidateValue checks that compareValue is an array of strings.
+func (o *InIgnoreCaseOperator) ValidateValue(compareValue interface{}) error {
+	if _, err := stringElements(compareValue); err != nil {
+		return &OperatorError{
+			Operator:     OperatorInIgnoreCase,
+			CompareValue: compareValue,
+			Err:          err,
+		}
+	}
+	return nil
+}
+
+// stringElements returns the elements of a slice or array of strings.
+func stringElements(value interface{}) ([]string, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("in_ignore_case operator requires an array or slice of strings as compareValue")
+	}
+
+	values := make([]string, rv.Len())
+	for i := range values {
+		s, ok := rv.Index(i).Interface().(string)
+		if !ok {
+			return nil, fmt.Errorf("in_ignore_case operator requires string elements, got %T at index %d", rv.Index(i).Interface(), i)
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+// evaluateLength compares the length of factValue (characters of a string,
+// elements of a slice, array or map) with the compareValue integer.
+func evaluateLength(op OperatorType, factValue interface{}, compareValue interface{}, compare func(length, n int) bool) (bool, error) {
+	var length int
+	rv := reflect.ValueOf(factValue)
+	switch rv.Kind() {
+	case reflect.String:
+		length = utf8.RuneCountInString(rv.String())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length = rv.Len()
+	default:
+		return false, &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a string, array, slice or map", op),
+		}
+	}
+
+	n, err := lengthValue(op, compareValue)
+	if err != nil {
+		return false, &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          err,
+		}
+	}
+	return compare(length, n), nil
+}
+
+// lengthValue converts the compareValue of a length operator to a non-negative integer.
+func lengthValue(op OperatorType, compareValue interface{}) (int, error) {
+	n, ok := toFloat64(compareValue)
+	if !ok || n < 0 || n != math.Trunc(n) || n > math.MaxInt32 {
+		return 0, fmt.Errorf("%s operator requires a non-negative integer value", op)
+	}
+	return int(n), nil
+}
+
+// validateLengthValue reports an OperatorError if compareValue is not a non-negative integer.
+func validateLengthValue(op OperatorType, compareValue interface{}) error {
+	if _, err := lengthValue(op, compareValue); err != nil {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          err,
+		}
+	}
+	return nil
+}
+
+// Evaluate checks if the length of factValue (characters of a string, elements of
+// a slice, array or map) equals compareValue.
+func (o *LengthEqualOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateLength(OperatorLengthEqual, factValue, compareValue, func(length, n int) bool { return length == n })
+}
+
+// ValidateValue checks that compareValue is a non-negative integer.
+func (o *LengthEqualOperator) ValidateValue(compareValue interface{}) error {
+	return validateLengthValue(OperatorLengthEqual, compareValue)
+}
+
+// Evaluate checks if the length of factValue is greater than compareValue.
+func (o *LengthGreaterThanOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateLength(OperatorLengthGreaterThan, factValue, compareValue, func(length, n int) bool { return length > n })
+}
+
+// ValidateValue checks that compareValue is a non-negative integer.
+func (o *LengthGreaterThanOperator) ValidateValue(compareValue interface{}) error {
+	return validateLengthValue(OperatorLengthGreaterThan, compareValue)
+}
+
+// Evaluate checks if the length of factValue is greater than or equal to compareValue.
+func (o *LengthGreaterThanInclusiveOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateLength(OperatorLengthGreaterThanInclusive, factValue, compareValue, func(length, n int) bool { return length >= n })
+}
+
+// ValidateValue checks that compareValue is a non-negative integer.
+func (o *LengthGreaterThanInclusiveOperator) ValidateValue(compareValue interface{}) error {
+	return validateLengthValue(OperatorLengthGreaterThanInclusive, compareValue)
+}
+
+// Evaluate checks if the length of factValue is less than compareValue.
+func (o *LengthLessThanOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateLength(OperatorLengthLessThan, factValue, compareValue, func(length, n int) bool { return length < n })
+}
+
+// ValidateValue checks that compareValue is a non-negative integer.
+func (o *LengthLessThanOperator) ValidateValue(compareValue interface{}) error {
+	return validateLengthValue(OperatorLengthLessThan, compareValue)
+}
+
+// Evaluate checks if the length of factValue is less than or equal to compareValue.
+func (o *LengthLessThanInclusiveOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return evaluateLength(OperatorLengthLessThanInclusive, factValue, compareValue, func(length, n int) bool { return length <= n })
+}
+
+// ValidateValue checks that compareValue is a non-negative integer.
+func (o *LengthLessThanInclusiveOperator) ValidateValue(compareValue interface{}) error {
+	return validateLengthValue(OperatorLengthLessThanInclusive, compareValue)
+}
@@ -0,0 +1,201 @@
+package gorulesengine
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FactPresenceOperator can be implemented by an Operator checking whether a fact
+// is present, to receive the FactStatus of the fact value. Its conditions read
+// the fact through Almanac.LookupFactValue, so an undefined fact or a path that
+// does not resolve is not an error, whatever the AllowUndefinedFacts option.
+type FactPresenceOperator interface {
+	EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error)
+}
+
+// ExistsOperator checks if the fact is defined and its path resolves.
+type ExistsOperator struct{}
+
+// NotExistsOperator checks if the fact is undefined or its path does not resolve.
+type NotExistsOperator struct{}
+
+// IsNullOperator checks if the fact exists with a nil value.
+type IsNullOperator struct{}
+
+// IsEmptyOperator checks if the fact is missing, nil, or an empty string, array or map.
+type IsEmptyOperator struct{}
+
+// IsNotEmptyOperator checks if the fact exists and is neither nil nor empty.
+type IsNotEmptyOperator struct{}
+
+// isNil reports whether value is nil or a nil pointer, map, slice or interface.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// isEmpty reports whether value is nil or an empty string, array, slice or map.
+func isEmpty(value interface{}) bool {
+	if isNil(value) {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// validatePresenceValue reports an OperatorError if a presence operator is given a value.
+func validatePresenceValue(op OperatorType, compareValue interface{}) error {
+	if compareValue != nil {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator takes no value", op),
+		}
+	}
+	return nil
+}
+
+// Evaluate assumes the fact was found, and always returns true.
+// Conditions call EvaluatePresence instead.
+func (o *ExistsOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluatePresence(FactFound, factValue, compareValue)
+}
+
+// EvaluatePresence checks if the fact was found, even with a nil value.
+func (o *ExistsOperator) EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error) {
+	return status == FactFound, nil
+}
+
+// ValidateValue checks that no value is given.
+func (o *ExistsOperator) ValidateValue(compareValue interface{}) error {
+	return validatePresenceValue(OperatorExists, compareValue)
+}
+
+// Evaluate assumes the fact was found, and always returns false.
+// Conditions call EvaluatePresence instead.
+func (o *NotExistsOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluatePresence(FactFound, factValue, compareValue)
+}
+
+// EvaluatePresence checks if the fact is undefined or its path does not resolve.
+// Returns the inverse of the ExistsOperator result.
+func (o *NotExistsOperator) EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error) {
+	return status != FactFound, nil
+}
+
+// ValidateValue checks that no value is given.
+func (o *NotExistsOperator) ValidateValue(compareValue interface{}) error {
+	return validatePresenceValue(OperatorNotExists, compareValue)
+}
+
+// Evaluate checks if factValue is nil, assuming the fact was found.
+func (o *IsNullOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluatePresence(FactFound, factValue, compareValue)
+}
+
+// EvaluatePresence checks if the fact was found with a nil value (or a nil
+// pointer, map or slice). A missing fact is not null.
+func (o *IsNullOperator) EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error) {
+	return status == FactFound && isNil(factValue), nil
+}
+
+// ValidateValue checks that no value is given.
+func (o *IsNullOperator) ValidateValue(compareValue interface{}) error {
+	return validatePresenceValue(OperatorIsNull, compareValue)
+}
+
+// Evaluate checks if factValue is nil or empty, assuming the fact was found.
+func (o *IsEmptyOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluatePresence(FactFound, factValue, compareValue)
+}
+
+// EvaluatePresence checks if the fact is missing, nil, or an empty string, array,
+// slice or map. Other values, such as 0 or false, are not empty.
+func (o *IsEmptyOperator) EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error) {
+	return status != FactFound || isEmpty(factValue), nil
+}
+
+// ValidateValue checks that no value is given.
+func (o *IsEmptyOperator) ValidateValue(compareValue interface{}) error {
+	return validatePresenceValue(OperatorIsEmpty, compareValue)
+}
+
+// Evaluate checks if factValue is neither nil nor empty, assuming the fact was found.
+func (o *IsNotEmptyOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	return o.EvaluatePresence(FactFound, factValue, compareValue)
+}
+
+// EvaluatePresence checks if the fact was found and is neither nil nor empty.
+// Returns the inverse of the IsEmptyOperator result.
+func (o *IsNotEmptyOperator) EvaluatePresence(status FactStatus, factValue interface{}, compareValue interface{}) (bool, error) {
+	return status == FactFound && !isEmpty(factValue), nil
+}
+
+// ValidateValue checks that no value is given.
+func (o *IsNotEmptyOperator) ValidateValue(compareValue interface{}) error {
+	return validatePresenceValue(OperatorIsNotEmpty, compareValue)
+}
+
+// checksPresence reports whether the operator of the condition checks the
+// presence of its fact, which is then not required to evaluate it.
+func (c *Condition) checksPresence() bool {
+	if c.Rule != "" {
+		return false
+	}
+	operator, err := GetOperator(c.Operator)
+	if err != nil {
+		return false
+	}
+	_, ok := operator.(FactPresenceOperator)
+	return ok
+}
+
+// requiredPresentFacts returns the facts that must be defined to evaluate the
+// condition set, leaving out those only read by presence operators.
+func requiredPresentFacts(cs *ConditionSet) []FactID {
+	var facts []FactID
+
+	var collect func(nodes []ConditionNode)
+	collect = func(nodes []ConditionNode) {
+		for _, node := range nodes {
+			switch {
+			case node.Condition != nil && node.Condition.checksPresence():
+				if ref, ok := asFactReference(node.Condition.Value); ok {
+					facts = append(facts, ref.Fact)
+				}
+			case node.Condition != nil:
+				facts = append(facts, node.Condition.GetRequiredFacts()...)
+			case node.SubSet != nil:
+				collect(node.SubSet.All)
+				collect(node.SubSet.Any)
+				collect(node.SubSet.None)
+			case node.Quantifier != nil:
+				facts = append(facts, node.Quantifier.Fact)
+				for _, fact := range requiredPresentFacts(&node.Quantifier.Where) {
+					if fact != node.Quantifier.element() {
+						facts = append(facts, fact)
+					}
+				}
+				if ref, ok := asFactReference(node.Quantifier.Value); ok {
+					facts = append(facts, ref.Fact)
+				}
+			}
+		}
+	}
+
+	collect(cs.All)
+	collect(cs.Any)
+	collect(cs.None)
+	return facts
+}
@@ -0,0 +1,211 @@
+package gorulesengine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+func TestAlmanac_LookupFactValue(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("customer", map[string]interface{}{
+		"address": map[string]interface{}{"city": "Paris", "zip": nil},
+		"tags":    []interface{}{},
+		"name":    "Jane",
+	})
+	almanac.AddFact("deletedAt", nil)
+	almanac.AddFact("visits", 0)
+
+	tests := []struct {
+		name     string
+		fact     gre.FactID
+		path     string
+		expected gre.FactStatus
+		value    interface{}
+	}{
+		{"found", "visits", "", gre.FactFound, 0},
+		{"explicit nil", "deletedAt", "", gre.FactFound, nil},
+		{"nested value", "customer", "$.address.city", gre.FactFound, "Paris"},
+		{"nested nil", "customer", "$.address.zip", gre.FactFound, nil},
+		{"undefined", "couponCode", "", gre.FactUndefined, nil},
+		{"missing key", "customer", "$.address.country", gre.FactPathNotFound, nil},
+		{"below a missing key", "customer", "$.billing.zip", gre.FactPathNotFound, nil},
+		{"below a nil value", "customer", "$.address.zip.code", gre.FactPathNotFound, nil},
+		{"index out of range", "customer", "$.tags[0]", gre.FactPathNotFound, nil},
+		{"path into a nil fact", "deletedAt", "$.by", gre.FactPathNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, status, err := almanac.LookupFactValue(context.Background(), tt.fact, nil, tt.path)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if status != tt.expected || value != tt.value {
+				t.Errorf("Expected %v (%v), got %v (%v)", tt.expected, tt.value, status, value)
+			}
+		})
+	}
+
+	if _, _, err := almanac.LookupFactValue(context.Background(), "customer", nil, "address.city"); err == nil {
+		t.Error("Expected an error for an invalid path")
+	}
+
+	// GetFactValue still reports unresolved paths as errors
+	_, err := almanac.GetFactValue("customer", nil, "$.address.country")
+	if !errors.Is(err, gre.ErrPathNotFound) {
+		t.Errorf("Expected an error wrapping ErrPathNotFound, got %v", err)
+	}
+}
+
+func TestPresenceOperators_Conditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		fact       string
+		path       string
+		exists     bool
+		isNull     bool
+		isEmpty    bool
+		isNotEmpty bool
+	}{
+		{"undefined fact", "couponCode", "", false, false, true, false},
+		{"missing path", "customer", "$.address.country", false, false, true, false},
+		{"explicit nil", "deletedAt", "", true, true, true, false},
+		{"nested nil", "customer", "$.address.zip", true, true, true, false},
+		{"empty array", "customer", "$.tags", true, false, true, false},
+		{"zero", "visits", "", true, false, false, true},
+		{"string", "customer", "$.name", true, false, false, true},
+		{"object", "customer", "$.address", true, false, false, true},
+	}
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("customer", map[string]interface{}{
+		"address": map[string]interface{}{"city": "Paris", "zip": nil},
+		"tags":    []interface{}{},
+		"name":    "Jane",
+	})
+	almanac.AddFact("deletedAt", nil)
+	almanac.AddFact("visits", 0)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := []struct {
+				condition *gre.Condition
+				expected  bool
+			}{
+				{gre.Exists(tt.fact, tt.path), tt.exists},
+				{gre.NotExists(tt.fact, tt.path), !tt.exists},
+				{gre.IsNull(tt.fact, tt.path), tt.isNull},
+				{gre.IsEmpty(tt.fact, tt.path), tt.isEmpty},
+				{gre.IsNotEmpty(tt.fact, tt.path), tt.isNotEmpty},
+			}
+			for _, check := range checks {
+				result, err := check.condition.Evaluate(almanac)
+				if err != nil {
+					t.Fatalf("%s: expected no error, got %v", check.condition.Operator, err)
+				}
+				if result.Result != check.expected {
+					t.Errorf("%s: expected %v, got %v", check.condition.Operator, check.expected, result.Result)
+				}
+			}
+		})
+	}
+}
+
+func TestPresenceOperators_AuditStatus(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("customer", map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}})
+
+	result, err := gre.NotExists("customer", "$.address.country").Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.FactStatus != gre.FactPathNotFound {
+		t.Errorf("Expected the %s status in the condition result, got %q", gre.FactPathNotFound, result.FactStatus)
+	}
+
+	// Other operators keep failing on unresolved paths
+	equal := &gre.Condition{Fact: "customer", Path: "$.address.country", Operator: gre.OperatorEqual, Value: "FR"}
+	_, err = equal.Evaluate(almanac)
+	if err == nil {
+		t.Error("Expected an error for equal on a missing path")
+	}
+}
+
+func TestPresenceOperators_ErrorsNotMasked(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("score", func() (interface{}, error) {
+		return nil, errors.New("scoring service unavailable")
+	})
+
+	var factErr *gre.FactError
+	if _, err := gre.NotExists("score", "").Evaluate(almanac); !errors.As(err, &factErr) {
+		t.Errorf("Expected the FactError of the dynamic fact, got %v", err)
+	}
+}
+
+func TestPresenceOperators_CachedConditions(t *testing.T) {
+	almanac := gre.NewAlmanac(gre.WithAlmanacConditionCaching())
+	condition := gre.Exists("couponCode", "")
+	if err := condition.Compile(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result, _ := condition.Evaluate(almanac); result.Result {
+		t.Fatal("Expected an undefined fact not to exist")
+	}
+	almanac.AddFact("couponCode", "WELCOME")
+	if result, _ := condition.Evaluate(almanac); !result.Result {
+		t.Error("Expected a fact defined after a cached evaluation to exist")
+	}
+}
+
+func TestPresenceOperators_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name: "coupon",
+		Conditions: gre.All(
+			gre.Exists("couponCode", ""),
+			&gre.Condition{Fact: "couponCode", Operator: gre.OperatorNotExists, Value: false},
+			&gre.Condition{Fact: "customer", Path: "$.tags", Operator: gre.OperatorIsEmpty, Value: []interface{}{}},
+		),
+	}
+
+	diags := gre.ValidateRule(rule)
+	if len(diags) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %v", diags)
+	}
+	for _, diag := range diags {
+		if diag.Code != gre.DiagnosticInvalidValue {
+			t.Errorf("Expected INVALID_VALUE diagnostics, got %v", diag)
+		}
+	}
+}
+
+func TestPresenceOperators_SmartSkip(t *testing.T) {
+	engine := gre.NewEngine(gre.WithSmartSkip())
+	engine.AddRule(&gre.Rule{
+		Name:       "no-coupon",
+		Conditions: gre.All(gre.NotExists("couponCode", ""), gre.Exists("customer", "$.address.city")),
+	})
+	engine.AddRule(&gre.Rule{
+		Name:       "coupon-prefix",
+		Conditions: gre.All(gre.Exists("couponCode", ""), gre.StartsWith("couponCode", "VIP")),
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("customer", map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	results := run.Results()
+	if res := results["no-coupon"]; res.Status == gre.RuleStatusSkipped || !res.Result {
+		t.Errorf("Expected the rule checking a missing fact to be evaluated and match, got %+v", res)
+	}
+	if res := results["coupon-prefix"]; res.Status != gre.RuleStatusSkipped {
+		t.Errorf("Expected the rule comparing a missing fact to be skipped, got %s", res.Status)
+	}
+}
@@ -1,6 +1,7 @@
 package gorulesengine_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -811,6 +812,250 @@ func TestRegexOperator_EvaluateInvalidTypes(t *testing.T) {
 	}
 }
 
+// Tests for BetweenOperator and NotBetweenOperator bounds
+func TestBetweenOperators_Bounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		fact     interface{}
+		value    interface{}
+		expected bool
+	}{
+		{"inside", 5, []interface{}{1, 10}, true},
+		{"min included", 1, []interface{}{1, 10}, true},
+		{"max included", 10.0, []int{1, 10}, true},
+		{"below", 0, []interface{}{1, 10}, false},
+		{"above", uint8(11), []interface{}{1.0, 10.0}, false},
+		{"exclusive min", 1, map[string]interface{}{"min": 1, "max": 10, "bounds": "()"}, false},
+		{"exclusive max", 10, map[string]interface{}{"min": 1, "max": 10, "bounds": "()"}, false},
+		{"half-open min", 1, map[string]interface{}{"min": 1, "max": 10, "bounds": "[)"}, true},
+		{"half-open max", 10, map[string]interface{}{"min": 1, "max": 10, "bounds": "[)"}, false},
+		{"half-open left", 10, map[string]interface{}{"min": 1, "max": 10, "bounds": "(]"}, true},
+		{"default bounds", 10, map[string]interface{}{"min": 1, "max": 10}, true},
+		{"single value range", 3, []interface{}{3, 3}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			between, err := evaluateOperator(t, gre.OperatorBetween, tt.fact, tt.value)
+			if err != nil || between != tt.expected {
+				t.Errorf("between: expected %v, got %v (%v)", tt.expected, between, err)
+			}
+			notBetween, err := evaluateOperator(t, gre.OperatorNotBetween, tt.fact, tt.value)
+			if err != nil || notBetween == tt.expected {
+				t.Errorf("not_between: expected %v, got %v (%v)", !tt.expected, notBetween, err)
+			}
+		})
+	}
+}
+
+// Test for BetweenOperator with invalid values
+func TestBetweenOperator_EvaluateInvalidTypes(t *testing.T) {
+	for _, value := range []interface{}{
+		[]interface{}{1},
+		[]interface{}{10, 1},
+		[]interface{}{"a", "z"},
+		map[string]interface{}{"min": 1},
+		map[string]interface{}{"min": 1, "max": 10, "bounds": "[["},
+		5,
+	} {
+		_, err := evaluateOperator(t, gre.OperatorNotBetween, 5, value)
+		if _, ok := err.(*gre.OperatorError); !ok {
+			t.Errorf("Expected an OperatorError for the range %v, got %v", value, err)
+		}
+	}
+
+	if _, err := evaluateOperator(t, gre.OperatorBetween, "5", []interface{}{1, 10}); err == nil {
+		t.Error("Expected an error for a non-numeric fact")
+	}
+}
+
+// Tests for the string operators
+func TestStringOperators_Evaluate(t *testing.T) {
+	tests := []struct {
+		op       gre.OperatorType
+		fact     interface{}
+		value    interface{}
+		expected bool
+	}{
+		{gre.OperatorStartsWith, "FR-75001", "FR-", true},
+		{gre.OperatorStartsWith, "FR-75001", "fr-", false},
+		{gre.OperatorStartsWith, "FR", "", true},
+		{gre.OperatorEndsWith, "invoice.pdf", ".pdf", true},
+		{gre.OperatorEndsWith, "invoice.pdf", ".PDF", false},
+		{gre.OperatorEqualIgnoreCase, "Straße", "STRASSE", false},
+		{gre.OperatorEqualIgnoreCase, "Gold", "gOLD", true},
+		{gre.OperatorEqualIgnoreCase, "Gold", "Golden", false},
+		{gre.OperatorInIgnoreCase, "Gold", []interface{}{"silver", "GOLD"}, true},
+		{gre.OperatorInIgnoreCase, "Gold", []string{"silver", "bronze"}, false},
+		{gre.OperatorInIgnoreCase, "Gold", []string{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v %v", tt.op, tt.fact, tt.value), func(t *testing.T) {
+			got, err := evaluateOperator(t, tt.op, tt.fact, tt.value)
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+}
+
+// Test for the string operators with invalid types
+func TestStringOperators_EvaluateInvalidTypes(t *testing.T) {
+	tests := []struct {
+		op    gre.OperatorType
+		fact  interface{}
+		value interface{}
+	}{
+		{gre.OperatorStartsWith, 12345, "12"},
+		{gre.OperatorStartsWith, "12345", 12},
+		{gre.OperatorEndsWith, []string{"a"}, "a"},
+		{gre.OperatorEqualIgnoreCase, "gold", nil},
+		{gre.OperatorInIgnoreCase, 1, []string{"1"}},
+		{gre.OperatorInIgnoreCase, "gold", "gold"},
+		{gre.OperatorInIgnoreCase, "gold", []interface{}{"gold", 1}},
+	}
+
+	for _, tt := range tests {
+		_, err := evaluateOperator(t, tt.op, tt.fact, tt.value)
+		if opErr, ok := err.(*gre.OperatorError); !ok || opErr.Operator != tt.op {
+			t.Errorf("%s(%v, %v): expected an OperatorError, got %v", tt.op, tt.fact, tt.value, err)
+		}
+	}
+}
+
+// Tests for the length operators on strings, slices and maps
+func TestLengthOperators_Evaluate(t *testing.T) {
+	facts := []interface{}{
+		"añb", // 3 characters, 4 bytes
+		[]int{1, 2, 3},
+		[3]string{"a", "b", "c"},
+		map[string]interface{}{"a": 1, "b": 2, "c": 3},
+	}
+	tests := []struct {
+		op       gre.OperatorType
+		value    interface{}
+		expected bool
+	}{
+		{gre.OperatorLengthEqual, 3, true},
+		{gre.OperatorLengthEqual, 4, false},
+		{gre.OperatorLengthGreaterThan, 2, true},
+		{gre.OperatorLengthGreaterThan, 3.0, false},
+		{gre.OperatorLengthGreaterThanInclusive, 3, true},
+		{gre.OperatorLengthLessThan, uint(3), false},
+		{gre.OperatorLengthLessThan, 4, true},
+		{gre.OperatorLengthLessThanInclusive, 3, true},
+		{gre.OperatorLengthLessThanInclusive, 2, false},
+	}
+
+	for _, fact := range facts {
+		for _, tt := range tests {
+			got, err := evaluateOperator(t, tt.op, fact, tt.value)
+			if err != nil || got != tt.expected {
+				t.Errorf("%s(%T, %v): expected %v, got %v (%v)", tt.op, fact, tt.value, tt.expected, got, err)
+			}
+		}
+	}
+
+	if empty, err := evaluateOperator(t, gre.OperatorLengthEqual, []interface{}{}, 0); err != nil || !empty {
+		t.Errorf("Expected an empty slice to have length 0, got %v (%v)", empty, err)
+	}
+}
+
+// Test for the length operators with invalid types
+func TestLengthOperators_EvaluateInvalidTypes(t *testing.T) {
+	tests := []struct {
+		fact  interface{}
+		value interface{}
+	}{
+		{12345, 5},
+		{nil, 0},
+		{"abc", -1},
+		{"abc", 1.5},
+		{"abc", "3"},
+	}
+
+	for _, tt := range tests {
+		_, err := evaluateOperator(t, gre.OperatorLengthGreaterThan, tt.fact, tt.value)
+		if _, ok := err.(*gre.OperatorError); !ok {
+			t.Errorf("(%v, %v): expected an OperatorError, got %v", tt.fact, tt.value, err)
+		}
+	}
+}
+
+// Test that the rule validation reports invalid values of the new operators
+func TestRangeStringLengthOperators_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name: "customer",
+		Conditions: gre.All(
+			gre.Between("age", 65, 18),
+			gre.BetweenExclusive("score", 0, 100),
+			gre.NotBetween("age", 18, 65),
+			gre.StartsWith("zip", "75"),
+			&gre.Condition{Fact: "zip", Operator: gre.OperatorEndsWith, Value: 1},
+			gre.EqualIgnoreCase("tier", "gold"),
+			&gre.Condition{Fact: "tier", Operator: gre.OperatorInIgnoreCase, Value: []interface{}{"gold", 1}},
+			gre.LengthGreaterThan("items", 0),
+			gre.LengthLessThanInclusive("name", -1),
+		),
+	}
+
+	diags := gre.ValidateRule(rule)
+	if len(diags) != 4 {
+		t.Fatalf("Expected 4 diagnostics, got %v", diags)
+	}
+	for _, diag := range diags {
+		if diag.Code != gre.DiagnosticInvalidValue {
+			t.Errorf("Expected INVALID_VALUE diagnostics, got %v", diag)
+		}
+	}
+}
+
+// Test the new operators through the engine with JSON rules
+func TestRangeStringLengthOperators_Engine(t *testing.T) {
+	var rule gre.Rule
+	data := `{"name": "premium", "conditions": {"all": [
+		{"fact": "age", "operator": "between", "value": {"min": 18, "max": 65, "bounds": "[)"}},
+		{"fact": "email", "operator": "ends_with", "value": "@example.com"},
+		{"fact": "tier", "operator": "in_ignore_case", "value": ["gold", "platinum"]},
+		{"fact": "cart", "operator": "length_greater_than_inclusive", "value": 2}
+	]}}`
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	engine := gre.NewEngine(gre.WithRuleValidation())
+	if err := engine.TryAddRule(&rule); err != nil {
+		t.Fatalf("Expected a valid rule, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		age      int
+		expected bool
+	}{
+		{"adult", 30, true},
+		{"upper bound excluded", 65, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("age", tt.age)
+			almanac.AddFact("email", "jane@example.com")
+			almanac.AddFact("tier", "Gold")
+			almanac.AddFact("cart", []string{"book", "pen"})
+
+			run, err := engine.Evaluate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := run.ReduceResults()["premium"]; got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 // Tests for GetOperator to verify operator retrieval
 func TestGetOperator(t *testing.T) {
 	tests := []struct {
@@ -873,6 +1118,61 @@ func TestGetOperator(t *testing.T) {
 			opType:     "regex",
 			wantExists: true,
 		},
+		{
+			name:       "Existing operator Between",
+			opType:     "between",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator NotBetween",
+			opType:     "not_between",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator StartsWith",
+			opType:     "starts_with",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator EndsWith",
+			opType:     "ends_with",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator EqualIgnoreCase",
+			opType:     "equal_ignore_case",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator InIgnoreCase",
+			opType:     "in_ignore_case",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator LengthEqual",
+			opType:     "length_equal",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator LengthGreaterThan",
+			opType:     "length_greater_than",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator LengthGreaterThanInclusive",
+			opType:     "length_greater_than_inclusive",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator LengthLessThan",
+			opType:     "length_less_than",
+			wantExists: true,
+		},
+		{
+			name:       "Existing operator LengthLessThanInclusive",
+			opType:     "length_less_than_inclusive",
+			wantExists: true,
+		},
 		{
 			name:       "Non-existing operator",
 			opType:     "non_existing_operator",
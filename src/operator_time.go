@@ -0,0 +1,466 @@
+package gorulesengine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BeforeOperator checks if the factValue time is before the compareValue time.
+type BeforeOperator struct{}
+
+// AfterOperator checks if the factValue time is after the compareValue time.
+type AfterOperator struct{}
+
+// BetweenDatesOperator checks if the factValue time is within a [from, to] range.
+type BetweenDatesOperator struct{}
+
+// WithinLastOperator checks if the factValue time is within a duration before now.
+type WithinLastOperator struct{}
+
+// OlderThanOperator checks if the factValue time is more than a duration before now.
+type OlderThanOperator struct{}
+
+// DayOfWeekInOperator checks if the factValue time falls on one of the given days of the week.
+type DayOfWeekInOperator struct{}
+
+// TimeOfDayBetweenOperator checks if the factValue time of day is within a [from, to) range.
+type TimeOfDayBetweenOperator struct{}
+
+// timeFormats are the string formats accepted as times, in order.
+var timeFormats = []string{time.RFC3339Nano, "2006-01-02"}
+
+// toTime converts a time.Time, an RFC3339 (or YYYY-MM-DD) string or a number of
+// seconds since the Unix epoch (in UTC) to a time.Time.
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		for _, layout := range timeFormats {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	seconds, ok := toFloat64(value)
+	if !ok || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, false
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC(), true
+}
+
+// toDuration converts a Go duration string (e.g. "720h") or a time.Duration to a
+// non-negative time.Duration.
+func toDuration(value interface{}) (time.Duration, error) {
+	var d time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		d = v
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, err
+		}
+		d = parsed
+	default:
+		return 0, fmt.Errorf("expected a duration string such as \"720h\", got %T", value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %s is negative", d)
+	}
+	return d, nil
+}
+
+// locations caches the time zones loaded by name.
+var locations sync.Map
+
+// loadLocation returns the time zone with the given IANA name, or nil for "".
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// inLocation returns t in loc, or t unchanged if loc is nil.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// timeOperands converts the fact and compare values of a time comparison.
+func timeOperands(op OperatorType, factValue, compareValue interface{}) (time.Time, time.Time, error) {
+	ft, ok := toTime(factValue)
+	if !ok {
+		return time.Time{}, time.Time{}, newTimeFactError(op, factValue, compareValue)
+	}
+	ct, ok := toTime(compareValue)
+	if !ok {
+		return time.Time{}, time.Time{}, &OperatorError{
+			Operator:     op,
+			Value:        factValue,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a time value (time.Time, RFC3339 string or epoch seconds)", op),
+		}
+	}
+	return ft, ct, nil
+}
+
+// factTime converts the fact value of a time operator.
+func factTime(op OperatorType, factValue, compareValue interface{}) (time.Time, error) {
+	t, ok := toTime(factValue)
+	if !ok {
+		return time.Time{}, newTimeFactError(op, factValue, compareValue)
+	}
+	return t, nil
+}
+
+// newTimeFactError reports a fact value that is not a time.
+func newTimeFactError(op OperatorType, factValue, compareValue interface{}) error {
+	return &OperatorError{
+		Operator:     op,
+		Value:        factValue,
+		CompareValue: compareValue,
+		Err:          fmt.Errorf("%s operator requires a time fact (time.Time, RFC3339 string or epoch seconds)", op),
+	}
+}
+
+// newTimeValueError reports an invalid compare value of a time operator.
+func newTimeValueError(op OperatorType, factValue, compareValue interface{}, err error) error {
+	return &OperatorError{
+		Operator:     op,
+		Value:        factValue,
+		CompareValue: compareValue,
+		Err:          fmt.Errorf("invalid %s value: %w", op, err),
+	}
+}
+
+// Evaluate checks if factValue is strictly before compareValue.
+// Both values must be times: time.Time, RFC3339 strings or epoch seconds.
+func (o *BeforeOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	ft, ct, err := timeOperands(OperatorBefore, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return ft.Before(ct), nil
+}
+
+// ValidateValue checks that compareValue is a time.
+func (o *BeforeOperator) ValidateValue(compareValue interface{}) error {
+	return validateTimeValue(OperatorBefore, compareValue)
+}
+
+// Evaluate checks if factValue is strictly after compareValue.
+// Both values must be times: time.Time, RFC3339 strings or epoch seconds.
+func (o *AfterOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	ft, ct, err := timeOperands(OperatorAfter, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	return ft.After(ct), nil
+}
+
+// ValidateValue checks that compareValue is a time.
+func (o *AfterOperator) ValidateValue(compareValue interface{}) error {
+	return validateTimeValue(OperatorAfter, compareValue)
+}
+
+// validateTimeValue reports an OperatorError if compareValue is not a time.
+func validateTimeValue(op OperatorType, compareValue interface{}) error {
+	if _, ok := toTime(compareValue); !ok {
+		return &OperatorError{
+			Operator:     op,
+			CompareValue: compareValue,
+			Err:          fmt.Errorf("%s operator requires a time value (time.Time, RFC3339 string or epoch seconds)", op),
+		}
+	}
+	return nil
+}
+
+// Evaluate checks if factValue is within the inclusive range given by compareValue,
+// either a [from, to] array or a {"from": ..., "to": ...} object of times.
+func (o *BetweenDatesOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	t, err := factTime(OperatorBetweenDates, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	from, to, err := parseDateRange(compareValue)
+	if err != nil {
+		return false, newTimeValueError(OperatorBetweenDates, factValue, compareValue, err)
+	}
+	return !t.Before(from) && !t.After(to), nil
+}
+
+// ValidateValue checks that compareValue is a range of times.
+func (o *BetweenDatesOperator) ValidateValue(compareValue interface{}) error {
+	if _, _, err := parseDateRange(compareValue); err != nil {
+		return newTimeValueError(OperatorBetweenDates, nil, compareValue, err)
+	}
+	return nil
+}
+
+// parseDateRange parses a [from, to] array or a {"from", "to"} object of times.
+func parseDateRange(value interface{}) (time.Time, time.Time, error) {
+	var bounds [2]interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) != 2 {
+			return time.Time{}, time.Time{}, fmt.Errorf("expected [from, to], got %d elements", len(v))
+		}
+		bounds = [2]interface{}{v[0], v[1]}
+	case map[string]interface{}:
+		bounds = [2]interface{}{v["from"], v["to"]}
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("expected [from, to] or {\"from\", \"to\"}, got %T", value)
+	}
+
+	from, ok := toTime(bounds[0])
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("from %v is not a time", bounds[0])
+	}
+	to, ok := toTime(bounds[1])
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("to %v is not a time", bounds[1])
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to %s is before from %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+	return from, to, nil
+}
+
+// Evaluate checks if factValue is within the compareValue duration (e.g. "720h")
+// before now, and not in the future.
+func (o *WithinLastOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	t, err := factTime(OperatorWithinLast, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	d, err := toDuration(compareValue)
+	if err != nil {
+		return false, newTimeValueError(OperatorWithinLast, factValue, compareValue, err)
+	}
+	now := time.Now()
+	return !t.Before(now.Add(-d)) && !t.After(now), nil
+}
+
+// ValidateValue checks that compareValue is a non-negative duration.
+func (o *WithinLastOperator) ValidateValue(compareValue interface{}) error {
+	if _, err := toDuration(compareValue); err != nil {
+		return newTimeValueError(OperatorWithinLast, nil, compareValue, err)
+	}
+	return nil
+}
+
+// Evaluate checks if factValue is more than the compareValue duration (e.g. "720h") before now.
+func (o *OlderThanOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	t, err := factTime(OperatorOlderThan, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	d, err := toDuration(compareValue)
+	if err != nil {
+		return false, newTimeValueError(OperatorOlderThan, factValue, compareValue, err)
+	}
+	return t.Before(time.Now().Add(-d)), nil
+}
+
+// ValidateValue checks that compareValue is a non-negative duration.
+func (o *OlderThanOperator) ValidateValue(compareValue interface{}) error {
+	if _, err := toDuration(compareValue); err != nil {
+		return newTimeValueError(OperatorOlderThan, nil, compareValue, err)
+	}
+	return nil
+}
+
+// Evaluate checks if factValue falls on one of the days in compareValue: an array
+// of days, or a {"days": [...], "timezone": "Europe/Paris"} object. Days are names
+// ("monday" or "mon", case-insensitive) or numbers from 0 (Sunday) to 6. Without
+// a timezone, the day is taken in the location of the time (UTC for epoch seconds).
+func (o *DayOfWeekInOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	t, err := factTime(OperatorDayOfWeekIn, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	days, loc, err := parseDaysOfWeek(compareValue)
+	if err != nil {
+		return false, newTimeValueError(OperatorDayOfWeekIn, factValue, compareValue, err)
+	}
+	return days[inLocation(t, loc).Weekday()], nil
+}
+
+// ValidateValue checks that compareValue holds valid days and time zone.
+func (o *DayOfWeekInOperator) ValidateValue(compareValue interface{}) error {
+	if _, _, err := parseDaysOfWeek(compareValue); err != nil {
+		return newTimeValueError(OperatorDayOfWeekIn, nil, compareValue, err)
+	}
+	return nil
+}
+
+// weekdays maps the names and abbreviations of the days of the week.
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseDaysOfWeek parses the days and time zone of a day_of_week_in value.
+func parseDaysOfWeek(value interface{}) (map[time.Weekday]bool, *time.Location, error) {
+	var list []interface{}
+	var loc *time.Location
+
+	switch v := value.(type) {
+	case []interface{}:
+		list = v
+	case map[string]interface{}:
+		var ok bool
+		if list, ok = v["days"].([]interface{}); !ok {
+			return nil, nil, fmt.Errorf("expected a 'days' array, got %T", v["days"])
+		}
+		var err error
+		if loc, err = parseTimezone(v["timezone"]); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("expected an array of days or {\"days\", \"timezone\"}, got %T", value)
+	}
+
+	days := make(map[time.Weekday]bool, len(list))
+	for _, item := range list {
+		if name, ok := item.(string); ok {
+			day, ok := weekdays[strings.ToLower(name)]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown day '%s'", name)
+			}
+			days[day] = true
+			continue
+		}
+		n, ok := toFloat64(item)
+		if !ok || n != math.Trunc(n) || n < 0 || n > 6 {
+			return nil, nil, fmt.Errorf("day %v is neither a name nor a number from 0 (Sunday) to 6", item)
+		}
+		days[time.Weekday(n)] = true
+	}
+	return days, loc, nil
+}
+
+// parseTimezone loads the time zone named by an optional 'timezone' value.
+func parseTimezone(value interface{}) (*time.Location, error) {
+	if value == nil {
+		return nil, nil
+	}
+	name, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a time zone name, got %T", value)
+	}
+	loc, err := loadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s'", name)
+	}
+	return loc, nil
+}
+
+// Evaluate checks if the time of day of factValue is within the range in compareValue:
+// a ["22:00", "06:00"] array or a {"from": "22:00", "to": "06:00", "timezone": "Europe/Paris"}
+// object. Times of day are HH:MM or HH:MM:SS; from is inclusive and to exclusive, and
+// a range with from after to spans midnight; from and to must differ. Without a timezone, the time of day is
+// taken in the location of the time (UTC for epoch seconds).
+func (o *TimeOfDayBetweenOperator) Evaluate(factValue interface{}, compareValue interface{}) (bool, error) {
+	t, err := factTime(OperatorTimeOfDayBetween, factValue, compareValue)
+	if err != nil {
+		return false, err
+	}
+	from, to, loc, err := parseTimeOfDayRange(compareValue)
+	if err != nil {
+		return false, newTimeValueError(OperatorTimeOfDayBetween, factValue, compareValue, err)
+	}
+
+	t = inLocation(t, loc)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if from <= to {
+		return clock >= from && clock < to, nil
+	}
+	return clock >= from || clock < to, nil
+}
+
+// ValidateValue checks that compareValue holds a valid time of day range and time zone.
+func (o *TimeOfDayBetweenOperator) ValidateValue(compareValue interface{}) error {
+	if _, _, _, err := parseTimeOfDayRange(compareValue); err != nil {
+		return newTimeValueError(OperatorTimeOfDayBetween, nil, compareValue, err)
+	}
+	return nil
+}
+
+// parseTimeOfDayRange parses the bounds, as durations since midnight, and the time
+// zone of a time_of_day_between value.
+func parseTimeOfDayRange(value interface{}) (time.Duration, time.Duration, *time.Location, error) {
+	var bounds [2]interface{}
+	var loc *time.Location
+
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) != 2 {
+			return 0, 0, nil, fmt.Errorf("expected [from, to], got %d elements", len(v))
+		}
+		bounds = [2]interface{}{v[0], v[1]}
+	case map[string]interface{}:
+		bounds = [2]interface{}{v["from"], v["to"]}
+		var err error
+		if loc, err = parseTimezone(v["timezone"]); err != nil {
+			return 0, 0, nil, err
+		}
+	default:
+		return 0, 0, nil, fmt.Errorf("expected [from, to] or {\"from\", \"to\", \"timezone\"}, got %T", value)
+	}
+
+	from, err := parseTimeOfDay(bounds[0])
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	to, err := parseTimeOfDay(bounds[1])
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if from == to {
+		return 0, 0, nil, fmt.Errorf("expected from and to to differ, got %v and %v", bounds[0], bounds[1])
+	}
+	return from, to, loc, nil
+}
+
+// parseTimeOfDay parses an HH:MM or HH:MM:SS time of day as a duration since midnight.
+func parseTimeOfDay(value interface{}) (time.Duration, error) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected a time of day such as \"22:00\", got %T", value)
+	}
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day '%s', expected HH:MM or HH:MM:SS", s)
+}
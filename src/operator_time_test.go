@@ -0,0 +1,272 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	_ "time/tzdata" // Time zones for the tests, whatever the system provides
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// evaluateOperator evaluates a registered operator.
+func evaluateOperator(t *testing.T, op gre.OperatorType, factValue, compareValue interface{}) (bool, error) {
+	t.Helper()
+	operator, err := gre.GetOperator(op)
+	if err != nil {
+		t.Fatalf("Expected operator %s to be registered, got %v", op, err)
+	}
+	return operator.Evaluate(factValue, compareValue)
+}
+
+func TestBeforeAfterOperators_TimeKinds(t *testing.T) {
+	// 2024-03-15T12:00:00Z in every accepted form
+	instant := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	kinds := []interface{}{
+		instant,
+		&instant,
+		"2024-03-15T12:00:00Z",
+		"2024-03-15T13:00:00+01:00",
+		instant.Unix(),
+		int(instant.Unix()),
+		float64(instant.Unix()),
+		json.Number("1710504000"),
+	}
+
+	for _, value := range kinds {
+		if before, err := evaluateOperator(t, gre.OperatorBefore, value, "2024-03-15T12:00:01Z"); err != nil || !before {
+			t.Errorf("%T(%v): expected before, got %v (%v)", value, value, before, err)
+		}
+		if after, err := evaluateOperator(t, gre.OperatorAfter, value, "2024-03-15"); err != nil || !after {
+			t.Errorf("%T(%v): expected after the start of the day, got %v (%v)", value, value, after, err)
+		}
+		if before, _ := evaluateOperator(t, gre.OperatorBefore, value, instant); before {
+			t.Errorf("%T(%v): expected not strictly before itself", value, value)
+		}
+	}
+
+	if _, err := evaluateOperator(t, gre.OperatorBefore, "yesterday", instant); err == nil {
+		t.Error("Expected an error for a fact that is not a time")
+	}
+	if _, err := evaluateOperator(t, gre.OperatorAfter, instant, true); err == nil {
+		t.Error("Expected an error for a value that is not a time")
+	}
+}
+
+func TestBetweenDatesOperator(t *testing.T) {
+	tests := []struct {
+		name     string
+		fact     interface{}
+		value    interface{}
+		expected bool
+	}{
+		{"array inside", "2024-03-15T12:00:00Z", []interface{}{"2024-03-01", "2024-03-31"}, true},
+		{"array from bound", "2024-03-01T00:00:00Z", []interface{}{"2024-03-01", "2024-03-31"}, true},
+		{"array outside", "2024-04-01T00:00:01Z", []interface{}{"2024-03-01", "2024-03-31"}, false},
+		{"object inside", 1710504000, map[string]interface{}{"from": "2024-03-15T11:00:00Z", "to": 1710504000}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateOperator(t, gre.OperatorBetweenDates, tt.fact, tt.value)
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	for _, value := range []interface{}{
+		[]interface{}{"2024-03-31"},
+		[]interface{}{"2024-03-31", "2024-03-01"},
+		map[string]interface{}{"from": "2024-03-01"},
+		"2024-03-01",
+	} {
+		if _, err := evaluateOperator(t, gre.OperatorBetweenDates, "2024-03-15", value); err == nil {
+			t.Errorf("Expected an error for the range %v", value)
+		}
+	}
+}
+
+func TestWithinLastOlderThanOperators(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		fact       interface{}
+		withinLast bool
+		olderThan  bool
+	}{
+		{"recent", now.Add(-24 * time.Hour), true, false},
+		{"old", now.Add(-45 * 24 * time.Hour).Format(time.RFC3339), false, true},
+		{"future", now.Add(time.Hour).Unix(), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := evaluateOperator(t, gre.OperatorWithinLast, tt.fact, "720h"); err != nil || got != tt.withinLast {
+				t.Errorf("within_last: expected %v, got %v (%v)", tt.withinLast, got, err)
+			}
+			if got, err := evaluateOperator(t, gre.OperatorOlderThan, tt.fact, 720*time.Hour); err != nil || got != tt.olderThan {
+				t.Errorf("older_than: expected %v, got %v (%v)", tt.olderThan, got, err)
+			}
+		})
+	}
+
+	for _, value := range []interface{}{"30 days", "-1h", 3600} {
+		if _, err := evaluateOperator(t, gre.OperatorWithinLast, now, value); err == nil {
+			t.Errorf("Expected an error for the duration %v", value)
+		}
+	}
+}
+
+func TestDayOfWeekInOperator(t *testing.T) {
+	// Saturday 23:30 UTC is Sunday 08:30 in Tokyo
+	saturdayNight := "2024-03-16T23:30:00Z"
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"names", []interface{}{"Saturday", "sun"}, true},
+		{"numbers", []interface{}{6}, true},
+		{"other days", []interface{}{"mon", 0}, false},
+		{"timezone", map[string]interface{}{"days": []interface{}{"sunday"}, "timezone": "Asia/Tokyo"}, true},
+		{"timezone other day", map[string]interface{}{"days": []interface{}{"saturday"}, "timezone": "Asia/Tokyo"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateOperator(t, gre.OperatorDayOfWeekIn, saturdayNight, tt.value)
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	for _, value := range []interface{}{
+		[]interface{}{"someday"},
+		[]interface{}{7},
+		map[string]interface{}{"days": []interface{}{"mon"}, "timezone": "Mars/Olympus"},
+		map[string]interface{}{"timezone": "UTC"},
+	} {
+		if _, err := evaluateOperator(t, gre.OperatorDayOfWeekIn, saturdayNight, value); err == nil {
+			t.Errorf("Expected an error for %v", value)
+		}
+	}
+}
+
+func TestTimeOfDayBetweenOperator(t *testing.T) {
+	night := map[string]interface{}{"from": "22:00", "to": "06:00", "timezone": "Europe/Paris"}
+
+	tests := []struct {
+		name     string
+		fact     interface{}
+		value    interface{}
+		expected bool
+	}{
+		{"before midnight", "2024-01-10T21:30:00Z", night, true}, // 22:30 in Paris
+		{"after midnight", "2024-01-10T04:59:59Z", night, true},  // 05:59:59 in Paris
+		{"end excluded", "2024-01-10T05:00:00Z", night, false},   // 06:00 in Paris
+		{"day", "2024-01-10T12:00:00Z", night, false},
+		{"summer time", "2024-07-10T20:30:00Z", night, true}, // 22:30 in Paris
+		{"same day range", "2024-01-10T09:15:00Z", []interface{}{"09:00", "17:30"}, true},
+		{"start included", "2024-01-10T09:00:00Z", []interface{}{"09:00:00", "17:30"}, true},
+		{"offset of the time", "2024-01-10T09:15:00+02:00", []interface{}{"09:00", "17:30"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateOperator(t, gre.OperatorTimeOfDayBetween, tt.fact, tt.value)
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	for _, value := range []interface{}{
+		[]interface{}{"9am", "5pm"},
+		[]interface{}{"09:00"},
+		[]interface{}{"00:00", "00:00"},
+		map[string]interface{}{"from": "22:00", "to": "06:00", "timezone": 1},
+	} {
+		if _, err := evaluateOperator(t, gre.OperatorTimeOfDayBetween, "2024-01-10T09:15:00Z", value); err == nil {
+			t.Errorf("Expected an error for %v", value)
+		}
+	}
+}
+
+func TestTimeOperators_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name: "time",
+		Conditions: gre.All(
+			gre.Before("createdAt", "not a time"),
+			gre.WithinLast("createdAt", 720*time.Hour),
+			&gre.Condition{Fact: "createdAt", Operator: gre.OperatorOlderThan, Value: "a month"},
+			gre.DayOfWeekIn("createdAt", "Nowhere/City", time.Monday),
+			gre.TimeOfDayBetween("createdAt", "22:00", "25:00", ""),
+			gre.TimeOfDayBetween("createdAt", "08:00", "08:00:00", ""),
+		),
+	}
+
+	diags := gre.ValidateRule(rule)
+	if len(diags) != 5 {
+		t.Fatalf("Expected 5 diagnostics, got %v", diags)
+	}
+	for _, diag := range diags {
+		if diag.Code != gre.DiagnosticInvalidValue {
+			t.Errorf("Expected INVALID_VALUE diagnostics, got %v", diag)
+		}
+	}
+}
+
+func TestTimeOperators_Engine(t *testing.T) {
+	rule := gre.NewRuleBuilder().
+		WithName("night-transfer").
+		WithConditions(gre.ConditionNode{SubSet: &gre.ConditionSet{All: []gre.ConditionNode{
+			{Condition: gre.OlderThan("accountCreatedAt", 24*time.Hour)},
+			{Condition: gre.TimeOfDayBetween("transactionAt", "22:00", "06:00", "Europe/Paris")},
+			{Condition: gre.DayOfWeekIn("transactionAt", "Europe/Paris", time.Saturday, time.Sunday)},
+			{Condition: gre.BetweenDates("transactionAt", "2024-01-01", "2024-12-31T23:59:59Z")},
+			{Condition: gre.After("transactionAt", gre.FactRef("accountCreatedAt", ""))},
+		}}}).
+		Build()
+
+	// Rules round trip through JSON
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var loaded gre.Rule
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	engine := gre.NewEngine(gre.WithRuleValidation())
+	if err := engine.TryAddRule(&loaded); err != nil {
+		t.Fatalf("Expected a valid rule, got %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		transactionAt interface{}
+		expected      bool
+	}{
+		{"saturday night in Paris", "2024-06-15T23:30:00+02:00", true},
+		{"saturday night as epoch seconds", time.Date(2024, 6, 15, 21, 30, 0, 0, time.UTC).Unix(), true},
+		{"monday night in Paris", "2024-06-17T23:30:00+02:00", false},
+		{"saturday afternoon in Paris", "2024-06-15T15:00:00+02:00", false},
+		{"before the account creation", "2024-05-25T23:30:00+02:00", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("accountCreatedAt", "2024-06-01T00:00:00Z")
+			almanac.AddFact("transactionAt", tt.transactionAt)
+
+			run, err := engine.Evaluate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := run.ReduceResults()["night-transfer"]; got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
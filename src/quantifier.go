@@ -0,0 +1,313 @@
+package gorulesengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Quantifier defines how the elements of a collection must match the nested
+// conditions of a QuantifierCondition.
+type Quantifier string
+
+const (
+	// QuantifierSome matches if at least one element matches.
+	QuantifierSome Quantifier = "some"
+	// QuantifierEvery matches if all elements match (true for an empty collection).
+	QuantifierEvery Quantifier = "every"
+	// QuantifierNoneOf matches if no element matches.
+	QuantifierNoneOf Quantifier = "none_of"
+	// QuantifierCount compares the number of matching elements using Operator and Value.
+	QuantifierCount Quantifier = "count"
+)
+
+// DefaultElementFact is the fact under which nested conditions read the current
+// element of a QuantifierCondition without an As name.
+const DefaultElementFact FactID = "$item"
+
+// QuantifierCondition applies a nested ConditionSet to each element of an array
+// fact (or of the array at Path). In the nested conditions, the current element
+// is the fact named As ("$item" by default), whose fields are read with Path.
+// A nil or missing collection is empty.
+//
+// Example:
+//
+//	// Any item is alcohol, in a quantity above 2
+//	quantifier := &gre.QuantifierCondition{
+//	    Fact:       "order",
+//	    Path:       "$.items",
+//	    Quantifier: gre.QuantifierSome,
+//	    Where: gre.All(
+//	        &gre.Condition{Fact: "$item", Path: "$.category", Operator: "equal", Value: "alcohol"},
+//	        &gre.Condition{Fact: "$item", Path: "$.quantity", Operator: "greater_than", Value: 2},
+//	    ),
+//	}
+type QuantifierCondition struct {
+	Fact       FactID                 `json:"fact" yaml:"fact"`                             // The fact holding the collection
+	Path       string                 `json:"path,omitempty" yaml:"path,omitempty"`         // Optional JSONPath to the collection
+	Params     map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`     // Optional parameters for a dynamic fact
+	Quantifier Quantifier             `json:"quantifier" yaml:"quantifier"`                 // some, every, none_of or count
+	As         FactID                 `json:"as,omitempty" yaml:"as,omitempty"`             // The fact name of the current element (default "$item")
+	Where      ConditionSet           `json:"where" yaml:"where"`                           // The conditions each element is checked against
+	Operator   OperatorType           `json:"operator,omitempty" yaml:"operator,omitempty"` // Compares the count of matching elements (count only)
+	Value      interface{}            `json:"value,omitempty" yaml:"value,omitempty"`       // The value the count is compared to (count only)
+}
+
+// element returns the fact name of the current element in the nested conditions.
+func (q *QuantifierCondition) element() FactID {
+	if q.As != "" {
+		return q.As
+	}
+	return DefaultElementFact
+}
+
+// GetRequiredFacts returns the facts required by the quantifier: its collection
+// fact and the facts of the nested conditions, except the current element.
+func (q *QuantifierCondition) GetRequiredFacts() []FactID {
+	facts := []FactID{q.Fact}
+	for _, fact := range q.Where.GetRequiredFacts() {
+		if fact != q.element() {
+			facts = append(facts, fact)
+		}
+	}
+	if ref, ok := asFactReference(q.Value); ok {
+		facts = append(facts, ref.Fact)
+	}
+	return facts
+}
+
+// Compile pre-calculates the cache keys of the nested conditions.
+func (q *QuantifierCondition) Compile() error {
+	if err := q.Where.Compile(); err != nil {
+		return &ConditionError{
+			Condition: Condition{Fact: q.Fact, Path: q.Path},
+			Err:       fmt.Errorf("failed to compile quantifier conditions: %w", err),
+		}
+	}
+	return nil
+}
+
+// Evaluate evaluates the quantifier against the almanac.
+func (q *QuantifierCondition) Evaluate(almanac *Almanac) (*QuantifierResult, error) {
+	return q.EvaluateContext(context.Background(), almanac)
+}
+
+// EvaluateContext evaluates the nested conditions for each element of the
+// collection, stopping as soon as the result is known (except for count), and
+// records the result of each evaluated element.
+func (q *QuantifierCondition) EvaluateContext(ctx context.Context, almanac *Almanac) (*QuantifierResult, error) {
+	result := &QuantifierResult{
+		Fact:       q.Fact,
+		Path:       q.Path,
+		Quantifier: q.Quantifier,
+		Operator:   q.Operator,
+		Value:      q.Value,
+		Elements:   make([]ElementResult, 0),
+	}
+	fail := func(msg string, err error) (*QuantifierResult, error) {
+		err = fmt.Errorf("%s: %w", msg, err)
+		result.Error = err.Error()
+		return result, &ConditionError{
+			Condition: Condition{Fact: q.Fact, Path: q.Path},
+			Err:       err,
+		}
+	}
+
+	switch q.Quantifier {
+	case QuantifierSome, QuantifierEvery, QuantifierNoneOf, QuantifierCount:
+	default:
+		return fail("invalid quantifier", fmt.Errorf("unknown quantifier '%s'", q.Quantifier))
+	}
+
+	collection, err := resolveFactValue(ctx, almanac, q.Fact, q.Params, q.Path)
+	if err != nil {
+		return fail("failed to get collection value", err)
+	}
+	elements, err := collectionElements(collection)
+	if err != nil {
+		return fail("invalid quantifier collection", err)
+	}
+
+	for i, element := range elements {
+		setRes, err := q.Where.EvaluateContext(withElement(ctx, q.element(), element), almanac)
+		elemRes := ElementResult{Index: i, Value: element, Conditions: setRes}
+		if err != nil {
+			result.Elements = append(result.Elements, elemRes)
+			return fail(fmt.Sprintf("failed to evaluate element %d", i), err)
+		}
+		elemRes.Result = setRes.Result
+		result.Elements = append(result.Elements, elemRes)
+
+		if setRes.Result {
+			result.Count++
+		}
+		if (q.Quantifier == QuantifierSome || q.Quantifier == QuantifierNoneOf) && setRes.Result {
+			break // Short-circuit
+		}
+		if q.Quantifier == QuantifierEvery && !setRes.Result {
+			break // Short-circuit
+		}
+	}
+
+	switch q.Quantifier {
+	case QuantifierSome:
+		result.Result = result.Count > 0
+	case QuantifierEvery:
+		result.Result = result.Count == len(result.Elements)
+	case QuantifierNoneOf:
+		result.Result = result.Count == 0
+	case QuantifierCount:
+		result.Result, err = q.compareCount(ctx, almanac, result)
+		if err != nil {
+			return fail("failed to compare count", err)
+		}
+	}
+
+	return result, nil
+}
+
+// compareCount compares the number of matching elements with Value using Operator.
+func (q *QuantifierCondition) compareCount(ctx context.Context, almanac *Almanac, result *QuantifierResult) (bool, error) {
+	operator, err := GetOperator(q.Operator)
+	if err != nil {
+		return false, err
+	}
+
+	compareValue := q.Value
+	if ref, ok := asFactReference(q.Value); ok {
+		compareValue, err = resolveFactValue(ctx, almanac, ref.Fact, ref.Params, ref.Path)
+		if err != nil {
+			return false, err
+		}
+		result.CompareValue = compareValue
+	}
+
+	if modal, ok := operator.(NumericModeOperator); ok {
+		return modal.EvaluateMode(result.Count, compareValue, numericModeFromContext(ctx))
+	}
+	return operator.Evaluate(result.Count, compareValue)
+}
+
+// collectionElements returns the elements of a slice or array, none for nil.
+func collectionElements(value interface{}) ([]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected an array or slice, got %T", value)
+	}
+
+	elements := make([]interface{}, rv.Len())
+	for i := range elements {
+		elements[i] = rv.Index(i).Interface()
+	}
+	return elements, nil
+}
+
+// elementBinding is the current element of an enclosing quantifier, chained to
+// the bindings of the outer quantifiers.
+type elementBinding struct {
+	fact  FactID
+	value interface{}
+	outer *elementBinding
+}
+
+// elementKey is the context key of the quantifier elements being evaluated.
+type elementKey struct{}
+
+// withElement returns a context binding fact to the current element of a quantifier.
+func withElement(ctx context.Context, fact FactID, value interface{}) context.Context {
+	outer, _ := ctx.Value(elementKey{}).(*elementBinding)
+	return context.WithValue(ctx, elementKey{}, &elementBinding{fact: fact, value: value, outer: outer})
+}
+
+// elementFromContext returns the element bound to fact by the innermost enclosing quantifier.
+func elementFromContext(ctx context.Context, fact FactID) (interface{}, bool) {
+	for b, _ := ctx.Value(elementKey{}).(*elementBinding); b != nil; b = b.outer {
+		if b.fact == fact {
+			return b.value, true
+		}
+	}
+	return nil, false
+}
+
+// conditionCachingEnabled reports whether condition results can be cached: not
+// within a quantifier, where they depend on the current element.
+func conditionCachingEnabled(ctx context.Context, almanac *Almanac) bool {
+	if ctx.Value(elementKey{}) != nil {
+		return false
+	}
+	return almanac.IsConditionCachingEnabled()
+}
+
+// resolveFactValue returns the value of a fact like Almanac.GetFactValueContext,
+// reading the elements bound by enclosing quantifiers first.
+func resolveFactValue(ctx context.Context, almanac *Almanac, factID FactID, params map[string]interface{}, path string) (interface{}, error) {
+	if element, ok := elementFromContext(ctx, factID); ok {
+		value, _, err := almanac.traverseElement(factID, element, path)
+		return value, err
+	}
+	return almanac.GetFactValueContext(ctx, factID, params, path)
+}
+
+// resolveFactStatus returns the value and status of a fact like
+// Almanac.LookupFactValue, reading the elements bound by enclosing quantifiers first.
+func resolveFactStatus(ctx context.Context, almanac *Almanac, factID FactID, params map[string]interface{}, path string) (interface{}, FactStatus, error) {
+	if element, ok := elementFromContext(ctx, factID); ok {
+		value, status, err := almanac.traverseElement(factID, element, path)
+		if status == FactPathNotFound {
+			return nil, status, nil
+		}
+		return value, status, err
+	}
+	return almanac.LookupFactValue(ctx, factID, params, path)
+}
+
+// traverseElement resolves path within the current element of a quantifier.
+// A path that does not resolve returns FactPathNotFound along with its error, if any.
+func (a *Almanac) traverseElement(factID FactID, element interface{}, path string) (interface{}, FactStatus, error) {
+	if path != "" && element == nil {
+		return nil, FactPathNotFound, nil
+	}
+
+	value, err := a.TraversePath(element, path)
+	if err != nil {
+		status := FactFound
+		if errors.Is(err, ErrPathNotFound) {
+			status = FactPathNotFound
+		}
+		return nil, status, &AlmanacError{
+			Payload: fmt.Sprintf("factID=%s, path=%s", factID, path),
+			Err:     fmt.Errorf("failed to resolve path '%s' for element '%s': %w", path, factID, err),
+		}
+	}
+	return value, FactFound, nil
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for QuantifierCondition.
+// The value and params are decoded with JSON semantics, like Condition.
+func (q *QuantifierCondition) UnmarshalYAML(node *yaml.Node) error {
+	type Alias QuantifierCondition
+	var aux Alias
+	if err := node.Decode(&aux); err != nil {
+		return err
+	}
+
+	value, err := yamlNodeValue(yamlMappingValue(node, "value"))
+	if err != nil {
+		return err
+	}
+	params, err := yamlParams(yamlMappingValue(node, "params"))
+	if err != nil {
+		return err
+	}
+
+	*q = QuantifierCondition(aux)
+	q.Value = value
+	q.Params = params
+	return nil
+}
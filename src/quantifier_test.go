@@ -0,0 +1,360 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+	"gopkg.in/yaml.v3"
+)
+
+// alcoholAbove returns the conditions matching alcohol items in a quantity above n.
+func alcoholAbove(n int) gre.ConditionSet {
+	return gre.All(
+		&gre.Condition{Fact: "$item", Path: "$.category", Operator: gre.OperatorEqual, Value: "alcohol"},
+		&gre.Condition{Fact: "$item", Path: "$.quantity", Operator: gre.OperatorGreaterThan, Value: n},
+	)
+}
+
+func TestQuantifier_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		quantifier *gre.QuantifierCondition
+		expected   bool
+		evaluated  int
+		count      int
+	}{
+		{"some matches", gre.Some("order", "$.items", alcoholAbove(2)), true, 2, 1},
+		{"some without match", gre.Some("order", "$.items", alcoholAbove(5)), false, 3, 0},
+		{"every without match", gre.Every("devices", "", gre.All(gre.Equal("$item", true))), false, 1, 0},
+		{"every trusted", gre.Every("devices", "", gre.All(&gre.Condition{Fact: "$item", Path: "$.trusted", Operator: gre.OperatorEqual, Value: true})), true, 2, 2},
+		{"none_of matches", gre.NoneOf("order", "$.items", alcoholAbove(2)), false, 2, 1},
+		{"none_of without match", gre.NoneOf("order", "$.items", alcoholAbove(5)), true, 3, 0},
+		{"count alcohol", gre.Count("order", "$.items", gre.All(gre.Equal("$item", "alcohol")), gre.OperatorEqual, 0), true, 3, 0},
+		{"count greater than", gre.Count("order", "$.items", alcoholAbove(0), gre.OperatorGreaterThanInclusive, 2), true, 3, 2},
+		{"count against a fact", gre.Count("order", "$.items", alcoholAbove(0), gre.OperatorLessThan, gre.FactRef("maxPrice", "")), true, 3, 2},
+	}
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("order", map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A1", "category": "food", "quantity": 1, "price": 4.5},
+			map[string]interface{}{"sku": "B2", "category": "alcohol", "quantity": 3, "price": 12.0},
+			map[string]interface{}{"sku": "C3", "category": "alcohol", "quantity": 1, "price": 30.0},
+		},
+	})
+	almanac.AddFact("devices", []map[string]interface{}{
+		{"id": "phone", "trusted": true},
+		{"id": "laptop", "trusted": true},
+	})
+	almanac.AddFact("maxPrice", 25)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.quantifier.Evaluate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result.Result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result.Result)
+			}
+			if len(result.Elements) != tt.evaluated || result.Count != tt.count {
+				t.Errorf("Expected %d evaluated elements and a count of %d, got %d and %d", tt.evaluated, tt.count, len(result.Elements), result.Count)
+			}
+			for i, elem := range result.Elements {
+				if elem.Index != i || elem.Conditions == nil || elem.Result != elem.Conditions.Result {
+					t.Errorf("Unexpected element result %+v", elem)
+				}
+			}
+		})
+	}
+}
+
+func TestQuantifier_EmptyAndInvalidCollections(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("empty", []interface{}{})
+	almanac.AddFact("user", map[string]interface{}{"name": "Jane", "devices": nil})
+
+	expected := map[gre.Quantifier]bool{
+		gre.QuantifierSome:   false,
+		gre.QuantifierEvery:  true,
+		gre.QuantifierNoneOf: true,
+	}
+	for _, fact := range []string{"empty", "undefined", "user.devices"} {
+		for quantifier, want := range expected {
+			q := &gre.QuantifierCondition{Fact: gre.FactID(fact), Quantifier: quantifier, Where: alcoholAbove(0)}
+			if fact == "user.devices" {
+				q.Fact, q.Path = "user", "$.devices"
+			}
+			result, err := q.Evaluate(almanac)
+			if err != nil || result.Result != want || len(result.Elements) != 0 {
+				t.Errorf("%s over %s: expected %v without elements, got %+v (%v)", quantifier, fact, want, result, err)
+			}
+		}
+	}
+
+	for _, q := range []*gre.QuantifierCondition{
+		gre.Some("user", "$.name", alcoholAbove(0)),
+		{Fact: "empty", Quantifier: "any", Where: alcoholAbove(0)},
+		gre.Count("empty", "", alcoholAbove(0), "unknown", 1),
+	} {
+		result, err := q.Evaluate(almanac)
+		if err == nil || result.Error == "" {
+			t.Errorf("Expected an error for %+v", q)
+		}
+	}
+}
+
+func TestQuantifier_ElementErrors(t *testing.T) {
+	// A missing element field fails the condition, unless checked by a presence operator
+	items := []interface{}{map[string]interface{}{"sku": "A1"}}
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("items", items)
+
+	result, err := gre.Some("items", "", gre.All(gre.GreaterThan("$item", 0))).Evaluate(almanac)
+	if err == nil {
+		t.Error("Expected an error comparing an object with a number")
+	}
+	if len(result.Elements) != 1 || !strings.Contains(result.Error, "element 0") {
+		t.Errorf("Expected the failing element in the result, got %+v", result)
+	}
+
+	missing := &gre.Condition{Fact: "$item", Path: "$.quantity", Operator: gre.OperatorGreaterThan, Value: 0}
+	if _, err := gre.Some("items", "", gre.All(missing)).Evaluate(almanac); err == nil {
+		t.Error("Expected an error for a missing element field")
+	}
+
+	result, err = gre.Every("items", "", gre.All(gre.NotExists("$item", "$.quantity"), gre.Exists("$item", "$.sku"))).Evaluate(almanac)
+	if err != nil || !result.Result {
+		t.Errorf("Expected presence operators to check element fields, got %v (%v)", result.Result, err)
+	}
+}
+
+func TestQuantifier_NestedQuantifiers(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("customer", map[string]interface{}{
+		"country": "FR",
+		"orders": []interface{}{
+			map[string]interface{}{"id": 1, "country": "FR", "lines": []interface{}{10, 20}},
+			map[string]interface{}{"id": 2, "country": "DE", "lines": []interface{}{500}},
+		},
+	})
+
+	// Some order shipped abroad has a line above 100
+	inner := gre.Some("$order", "$.lines", gre.All(gre.GreaterThan("$item", 100)))
+	abroad := &gre.Condition{Fact: "$order", Path: "$.country", Operator: gre.OperatorNotEqual,
+		Value: gre.FactRef("customer", "$.country")}
+	outer := &gre.QuantifierCondition{
+		Fact:       "customer",
+		Path:       "$.orders",
+		Quantifier: gre.QuantifierSome,
+		As:         "$order",
+		Where:      gre.ConditionSet{All: []gre.ConditionNode{{Condition: abroad}, {Quantifier: inner}}},
+	}
+
+	result, err := outer.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Result || len(result.Elements) != 2 {
+		t.Fatalf("Expected the second order to match, got %+v", result)
+	}
+	nested := result.Elements[1].Conditions.Results[1].Quantifier
+	if nested == nil || !nested.Result || nested.Elements[0].Value != 500 {
+		t.Errorf("Expected the nested quantifier result in the trace, got %+v", nested)
+	}
+
+	for _, fact := range outer.GetRequiredFacts() {
+		if fact != "customer" {
+			t.Errorf("Expected only the customer fact to be required, got %v", fact)
+		}
+	}
+}
+
+func TestQuantifier_ConditionCaching(t *testing.T) {
+	// Cached condition results must not leak from one element to the next
+	engine := gre.NewEngine(gre.WithConditionCaching())
+	engine.AddRule(&gre.Rule{
+		Name: "two-alcohol-items",
+		Conditions: gre.ConditionSet{All: []gre.ConditionNode{
+			{Quantifier: gre.Count("order", "$.items", gre.All(gre.Equal("$item", "alcohol")), gre.OperatorEqual, 0)},
+			{Quantifier: gre.Count("order", "$.items", alcoholAbove(0), gre.OperatorEqual, 2)},
+		}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("order", map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A1", "category": "food", "quantity": 1, "price": 4.5},
+			map[string]interface{}{"sku": "B2", "category": "alcohol", "quantity": 3, "price": 12.0},
+			map[string]interface{}{"sku": "C3", "category": "alcohol", "quantity": 1, "price": 30.0},
+		},
+	})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !run.ReduceResults()["two-alcohol-items"] {
+		t.Errorf("Expected the rule to match, got %+v", run.Results()["two-alcohol-items"].Conditions)
+	}
+}
+
+func TestQuantifier_JSONAndYAML(t *testing.T) {
+	data := `{"name": "restricted", "conditions": {"any": [
+		{"fact": "order", "path": "$.items", "quantifier": "some", "where": {"all": [
+			{"fact": "$item", "path": "$.category", "operator": "equal", "value": "alcohol"},
+			{"fact": "$item", "path": "$.price", "operator": "greater_than", "value": {"fact": "maxPrice"}}
+		]}},
+		{"fact": "devices", "quantifier": "count", "as": "device",
+			"where": {"none": [{"fact": "device", "path": "$.trusted", "operator": "equal", "value": true}]},
+			"operator": "greater_than", "value": 0}
+	]}}`
+
+	var fromJSON gre.Rule
+	if err := json.Unmarshal([]byte(data), &fromJSON); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	q := fromJSON.Conditions.Any[1].Quantifier
+	if fromJSON.Conditions.Any[0].Quantifier == nil || q == nil || q.As != "device" || q.Value != float64(0) {
+		t.Fatalf("Expected quantifier nodes, got %+v", fromJSON.Conditions.Any)
+	}
+
+	out, err := yaml.Marshal(&fromJSON)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var fromYAML gre.Rule
+	if err := yaml.Unmarshal(out, &fromYAML); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	jsonOut, _ := json.Marshal(&fromJSON)
+	yamlOut, _ := json.Marshal(&fromYAML)
+	if string(jsonOut) != string(yamlOut) {
+		t.Errorf("Expected the same rule through YAML\n got: %s\nwant: %s", yamlOut, jsonOut)
+	}
+
+	engine := gre.NewEngine(gre.WithRuleValidation(), gre.WithAuditTrace())
+	if err := engine.TryAddRule(&fromJSON); err != nil {
+		t.Fatalf("Expected a valid rule, got %v", err)
+	}
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("order", map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A1", "category": "food", "quantity": 1, "price": 4.5},
+			map[string]interface{}{"sku": "B2", "category": "alcohol", "quantity": 3, "price": 12.0},
+			map[string]interface{}{"sku": "C3", "category": "alcohol", "quantity": 1, "price": 30.0},
+		},
+	})
+	almanac.AddFact("devices", []map[string]interface{}{
+		{"id": "phone", "trusted": true},
+		{"id": "laptop", "trusted": true},
+	})
+	almanac.AddFact("maxPrice", 25)
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The audit trace holds the result of each element
+	res := run.Results()["restricted"]
+	if !res.Result {
+		t.Fatalf("Expected the rule to match, got %+v", res)
+	}
+	elements := res.Conditions.Results[0].Quantifier.Elements
+	if len(elements) != 3 || elements[1].Result || !elements[2].Result {
+		t.Errorf("Expected the third item to match, got %+v", elements)
+	}
+	if ref := elements[2].Conditions.Results[1].Condition; ref.CompareValue != 25 {
+		t.Errorf("Expected the referenced fact in the element trace, got %+v", ref)
+	}
+}
+
+func TestQuantifier_Validation(t *testing.T) {
+	rule := &gre.Rule{
+		Name: "quantifiers",
+		Conditions: gre.ConditionSet{All: []gre.ConditionNode{
+			{Quantifier: gre.Some("order", "$.items", alcoholAbove(2))},
+			{Quantifier: &gre.QuantifierCondition{Fact: "order", Quantifier: "most", Where: alcoholAbove(2)}},
+			{Quantifier: gre.Count("order", "$.items", alcoholAbove(2), "about", 2)},
+			{Quantifier: gre.Count("order", "$.items", alcoholAbove(2), gre.OperatorIn, 2)},
+			{Quantifier: gre.Every("", "", gre.All(gre.Regex("$item", "[")))},
+		}},
+	}
+
+	codes := make([]string, 0)
+	for _, diag := range gre.ValidateRule(rule) {
+		codes = append(codes, diag.Path+" "+string(diag.Code))
+	}
+	sort.Strings(codes)
+	expected := []string{
+		"$.conditions.all[1].quantifier INVALID_QUANTIFIER",
+		"$.conditions.all[2].operator UNKNOWN_OPERATOR",
+		"$.conditions.all[3].value INVALID_VALUE",
+		"$.conditions.all[4].fact MISSING_FACT",
+		"$.conditions.all[4].where.all[0].value INVALID_VALUE",
+	}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("Expected diagnostics %v, got %v", expected, codes)
+	}
+}
+
+func TestQuantifier_SmartSkipAndBuilder(t *testing.T) {
+	rule := gre.NewRuleBuilder().
+		WithName("untrusted-device").
+		WithConditions(gre.ConditionNode{Quantifier: gre.Some("devices", "", gre.All(
+			&gre.Condition{Fact: "$item", Path: "$.trusted", Operator: gre.OperatorEqual, Value: false},
+		))}).
+		Build()
+
+	if facts := rule.GetRequiredFacts(); len(facts) != 1 || facts[0] != "devices" {
+		t.Errorf("Expected only the devices fact to be required, got %v", facts)
+	}
+
+	engine := gre.NewEngine(gre.WithSmartSkip())
+	engine.AddRule(rule)
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("devices", []map[string]interface{}{
+		{"id": "phone", "trusted": true},
+		{"id": "laptop", "trusted": true},
+	})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if res := run.Results()["untrusted-device"]; res.Status == gre.RuleStatusSkipped || res.Result {
+		t.Errorf("Expected the rule to be evaluated and fail, got %+v", res)
+	}
+
+	run, _ = engine.Evaluate(gre.NewAlmanac())
+	if res := run.Results()["untrusted-device"]; res.Status != gre.RuleStatusSkipped {
+		t.Errorf("Expected the rule to be skipped without devices, got %s", res.Status)
+	}
+}
+
+func TestQuantifier_SmartSkipCountAgainstFact(t *testing.T) {
+	engine := gre.NewEngine(gre.WithSmartSkip())
+	engine.AddRule(&gre.Rule{
+		Name: "too-many-devices",
+		Conditions: gre.ConditionSet{All: []gre.ConditionNode{{Quantifier: gre.Count("devices", "", gre.All(
+			&gre.Condition{Fact: "$item", Path: "$.trusted", Operator: gre.OperatorEqual, Value: false},
+		), gre.OperatorGreaterThan, gre.FactRef("maxDevices", ""))}}},
+	})
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("devices", []map[string]interface{}{{"id": "phone", "trusted": false}})
+
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if res := run.Results()["too-many-devices"]; res.Status != gre.RuleStatusSkipped {
+		t.Errorf("Expected the rule to be skipped without maxDevices, got %+v", res)
+	}
+}
@@ -1,13 +1,15 @@
 package gorulesengine
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 )
 
 // RuleEvent represents an event reference within a rule, optionally with parameters.
 type RuleEvent struct {
-	Name   string                 `json:"name"`
-	Params map[string]interface{} `json:"params,omitempty"`
+	Name   string                 `json:"name" yaml:"name"`
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for RuleEvent.
@@ -31,6 +33,29 @@ func (re *RuleEvent) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements custom JSON marshaling for RuleEvent.
+// Events without parameters are written in the short string form, mirroring UnmarshalJSON.
+func (re RuleEvent) MarshalJSON() ([]byte, error) {
+	if len(re.Params) == 0 {
+		return json.Marshal(re.Name)
+	}
+
+	type Alias RuleEvent
+	return json.Marshal(Alias(re))
+}
+
+// StopMode tells after which outcome of a rule the engine stops evaluating the next rules.
+type StopMode string
+
+const (
+	// StopOnSuccess halts the run when the rule succeeds.
+	StopOnSuccess StopMode = "success"
+	// StopOnFailure halts the run when the rule fails.
+	StopOnFailure StopMode = "failure"
+	// StopAlways halts the run once the rule is evaluated.
+	StopAlways StopMode = "always"
+)
+
 // Rule represents a business rule with conditions and an associated event.
 // Rules are evaluated against facts in an Almanac. When all conditions are met,
 // the rule's event is triggered and any registered callbacks are invoked.
@@ -54,12 +79,14 @@ func (re *RuleEvent) UnmarshalJSON(data []byte) error {
 //	    },
 //	}
 type Rule struct {
-	Name       string       `json:"name,omitempty"`
-	Priority   int          `json:"priority,omitempty"` // Higher priority rules are evaluated first
-	Conditions ConditionSet `json:"conditions"`
-	OnSuccess  []RuleEvent  `json:"onSuccess,omitempty"` // Events to invoke on success
-	OnFailure  []RuleEvent  `json:"onFailure,omitempty"` // Events to invoke on failure
-	Result     bool
+	Name           string       `json:"name,omitempty" yaml:"name,omitempty"`
+	Priority       int          `json:"priority,omitempty" yaml:"priority,omitempty"` // Higher priority rules are evaluated first
+	Conditions     ConditionSet `json:"conditions" yaml:"conditions"`
+	OnSuccess      []RuleEvent  `json:"onSuccess,omitempty" yaml:"onSuccess,omitempty"`           // Events to invoke on success
+	OnFailure      []RuleEvent  `json:"onFailure,omitempty" yaml:"onFailure,omitempty"`           // Events to invoke on failure
+	Effect         RuleEffect   `json:"effect,omitempty" yaml:"effect,omitempty"`                 // Decision voted for when the rule matches, under override hit policies
+	StopProcessing StopMode     `json:"stopProcessing,omitempty" yaml:"stopProcessing,omitempty"` // Halts the run after this rule, depending on its outcome
+	Result         bool         `json:"-" yaml:"-"`
 }
 
 // GetRequiredFacts returns the list of all facts required by this rule.
@@ -71,3 +98,17 @@ func (r *Rule) GetRequiredFacts() []FactID {
 func (r *Rule) Compile() error {
 	return r.Conditions.Compile()
 }
+
+// Hash returns a content hash of the rule, computed from its JSON form.
+// Two rules with the same definition have the same hash.
+func (r *Rule) Hash() (string, error) {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return "", &RuleError{
+			Rule: *r,
+			Err:  err,
+		}
+	}
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
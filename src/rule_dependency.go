@@ -0,0 +1,193 @@
+package gorulesengine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GetRequiredRules returns the names of the rules referenced by this condition.
+func (c *Condition) GetRequiredRules() []string {
+	if c.Rule == "" {
+		return nil
+	}
+	return []string{c.Rule}
+}
+
+// GetRequiredRules returns the names of the rules referenced by this condition node.
+func (n *ConditionNode) GetRequiredRules() []string {
+	if n.Condition != nil {
+		return n.Condition.GetRequiredRules()
+	} else if n.SubSet != nil {
+		return n.SubSet.GetRequiredRules()
+	} else if n.Quantifier != nil {
+		return n.Quantifier.Where.GetRequiredRules()
+	}
+	return nil
+}
+
+// GetRequiredRules returns the names of all rules referenced by this condition set.
+func (cs *ConditionSet) GetRequiredRules() []string {
+	ruleMap := make(map[string]bool)
+	for _, nodes := range [][]ConditionNode{cs.All, cs.Any, cs.None} {
+		for i := range nodes {
+			for _, name := range nodes[i].GetRequiredRules() {
+				ruleMap[name] = true
+			}
+		}
+	}
+
+	rules := make([]string, 0, len(ruleMap))
+	for name := range ruleMap {
+		rules = append(rules, name)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// GetRequiredRules returns the names of the rules this rule depends on,
+// through rule conditions ({"rule": "name", ...}).
+func (r *Rule) GetRequiredRules() []string {
+	return r.Conditions.GetRequiredRules()
+}
+
+// ruleGraph holds the dependencies between rules, as indexes into a rule slice.
+type ruleGraph struct {
+	rules []*Rule
+	deps  [][]int // deps[i] lists the rules rule i depends on
+}
+
+// newRuleGraph builds the dependency graph of rules. A reference to a name
+// shared by several rules depends on all of them; references to unknown rules
+// are ignored (they fail at evaluation time).
+func newRuleGraph(rules []*Rule) *ruleGraph {
+	byName := make(map[string][]int, len(rules))
+	for i, rule := range rules {
+		byName[rule.Name] = append(byName[rule.Name], i)
+	}
+
+	g := &ruleGraph{rules: rules, deps: make([][]int, len(rules))}
+	for i, rule := range rules {
+		for _, name := range rule.GetRequiredRules() {
+			g.deps[i] = append(g.deps[i], byName[name]...)
+		}
+	}
+	return g
+}
+
+// hasDependencies reports whether any rule depends on another one.
+func (g *ruleGraph) hasDependencies() bool {
+	for _, deps := range g.deps {
+		if len(deps) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findCycle returns the names of the rules forming a dependency cycle
+// (first rule repeated at the end), or nil if there is none.
+func (g *ruleGraph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.rules))
+	var stack []int
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, dep := range g.deps[i] {
+			switch state[dep] {
+			case visiting:
+				var cycle []string
+				for j := len(stack) - 1; j >= 0; j-- {
+					cycle = append([]string{g.rules[stack[j]].Name}, cycle...)
+					if stack[j] == dep {
+						break
+					}
+				}
+				return append(cycle, g.rules[dep].Name)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range g.rules {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// levels groups the rules in dependency levels: every rule comes after the rules
+// it depends on, and rules of a level only depend on earlier levels.
+// Within a level, rules keep their order in the slice (e.g. priority order).
+func (g *ruleGraph) levels() ([][]*Rule, error) {
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, newRuleCycleError(cycle)
+	}
+
+	level := make([]int, len(g.rules))
+	computed := make([]bool, len(g.rules))
+	var compute func(i int) int
+	compute = func(i int) int {
+		if !computed[i] {
+			for _, dep := range g.deps[i] {
+				if l := compute(dep) + 1; l > level[i] {
+					level[i] = l
+				}
+			}
+			computed[i] = true
+		}
+		return level[i]
+	}
+
+	var levels [][]*Rule
+	for i, rule := range g.rules {
+		l := compute(i)
+		for len(levels) <= l {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], rule)
+	}
+	return levels, nil
+}
+
+// orderRulesByDependencies returns the rules in dependency levels.
+// Without rule conditions, all rules are in a single level and keep their order.
+func orderRulesByDependencies(rules []*Rule) ([][]*Rule, error) {
+	g := newRuleGraph(rules)
+	if !g.hasDependencies() {
+		return [][]*Rule{rules}, nil
+	}
+	return g.levels()
+}
+
+// newRuleCycleError creates the ValidationError reported for a dependency cycle.
+func newRuleCycleError(cycle []string) error {
+	return Diagnostics{newRuleCycleDiagnostic(cycle)}.Err()
+}
+
+// newRuleCycleDiagnostic creates the diagnostic reported for a dependency cycle.
+func newRuleCycleDiagnostic(cycle []string) Diagnostic {
+	return Diagnostic{
+		Rule:     cycle[0],
+		Path:     "$.conditions",
+		Severity: SeverityError,
+		Code:     DiagnosticRuleCycle,
+		Message:  fmt.Sprintf("rule conditions form a cycle: %s", strings.Join(cycle, " -> ")),
+	}
+}
@@ -0,0 +1,245 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleRef creates a rule condition on the result of another rule.
+func ruleRef(name string, fired bool) *gre.Condition {
+	return &gre.Condition{Rule: name, Operator: gre.OperatorEqual, Value: fired}
+}
+
+func TestRuleChaining(t *testing.T) {
+	rules := []*gre.Rule{
+		{
+			Name:       "block",
+			Priority:   100,
+			Conditions: gre.All(ruleRef("high-risk-transaction", true), ruleRef("vip-discount", false)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "high-risk-transaction",
+			Priority:   1,
+			Conditions: gre.All(gre.GreaterThan("amount", 1000)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "vip-discount",
+			Priority:   50,
+			Conditions: gre.All(gre.Equal("tier", "vip")),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+		{
+			Name:       "audit",
+			Priority:   10,
+			Conditions: gre.Any(ruleRef("block", true)),
+			OnSuccess:  []gre.RuleEvent{{Name: "record"}},
+		},
+	}
+
+	tests := []struct {
+		tier     string
+		amount   int
+		blocked  bool
+		expected []string
+	}{
+		{"regular", 5000, true, []string{"high-risk-transaction", "block", "audit"}},
+		{"vip", 5000, false, []string{"vip-discount", "high-risk-transaction"}},
+		{"regular", 10, false, nil},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("parallel=%v/%s/%d", parallel, tt.tier, tt.amount), func(t *testing.T) {
+				opts := []gre.EngineOption{gre.WithAuditTrace()}
+				if parallel {
+					opts = append(opts, gre.WithParallelExecution(4))
+				}
+				engine := gre.NewEngine(opts...)
+
+				var fired []string
+				engine.RegisterEvent(gre.Event{
+					Name: "record",
+					Action: func(ctx gre.EventContext) error {
+						fired = append(fired, ctx.RuleName)
+						return nil
+					},
+				})
+				if err := engine.TryAddRules(rules...); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+
+				almanac := gre.NewAlmanac()
+				almanac.AddFact("tier", tt.tier)
+				almanac.AddFact("amount", tt.amount)
+
+				res, err := engine.Evaluate(almanac)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if res.ReduceResults()["block"] != tt.blocked {
+					t.Errorf("Expected block=%v, got %v", tt.blocked, res.ReduceResults())
+				}
+				if res.ReduceResults()["audit"] != tt.blocked {
+					t.Errorf("Expected audit=%v, got %v", tt.blocked, res.ReduceResults())
+				}
+				if fmt.Sprint(fired) != fmt.Sprint(tt.expected) {
+					t.Errorf("Expected events in dependency order %v, got %v", tt.expected, fired)
+				}
+
+				cond := res.Results()["block"].Conditions.Results[0].Condition
+				if cond.Rule != "high-risk-transaction" || cond.FactValue != (tt.amount > 1000) {
+					t.Errorf("Expected audit trace of the rule condition, got %+v", cond)
+				}
+			})
+		}
+	}
+}
+
+func TestRuleChaining_Cycles(t *testing.T) {
+	a := &gre.Rule{Name: "a", Conditions: gre.All(ruleRef("b", true))}
+	b := &gre.Rule{Name: "b", Conditions: gre.All(ruleRef("c", true))}
+	c := &gre.Rule{Name: "c", Conditions: gre.All(ruleRef("a", false))}
+	self := &gre.Rule{Name: "self", Conditions: gre.All(ruleRef("self", true))}
+
+	t.Run("rejected when added", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.AddRules(a, b)
+
+		err := engine.TryAddRule(c)
+		var valErr *gre.ValidationError
+		if !errors.As(err, &valErr) || valErr.Diagnostics[0].Code != gre.DiagnosticRuleCycle {
+			t.Fatalf("Expected rule cycle error, got %v", err)
+		}
+		if msg := valErr.Diagnostics[0].Message; msg != "rule conditions form a cycle: a -> b -> c -> a" {
+			t.Errorf("Unexpected cycle message: %s", msg)
+		}
+
+		engine.AddRule(c)
+		engine.AddRules(self)
+		if len(engine.GetRules()) != 2 {
+			t.Errorf("Expected cyclic rules to be skipped, got %d rules", len(engine.GetRules()))
+		}
+	})
+
+	t.Run("rejected when set", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.AddRule(a)
+
+		if err := engine.TrySetRules([]*gre.Rule{a, b, c}); err == nil {
+			t.Fatal("Expected rule cycle error")
+		}
+		engine.SetRules([]*gre.Rule{self})
+		if rules := engine.GetRules(); len(rules) != 1 || rules[0] != a {
+			t.Errorf("Expected previous rules to be kept, got %d rules", len(rules))
+		}
+	})
+
+	t.Run("detected at run time", func(t *testing.T) {
+		// The rule is modified after being added
+		selfLater := &gre.Rule{Name: "self", Conditions: gre.All(gre.Equal("x", 1))}
+		engines := []*gre.Engine{gre.NewEngine(), gre.NewEngine(gre.WithParallelExecution(2))}
+		for _, engine := range engines {
+			engine.AddRule(selfLater)
+		}
+		selfLater.Conditions = gre.All(ruleRef("self", true))
+
+		if diag := findDiagnostic(engines[0].ValidateRules(), gre.DiagnosticRuleCycle, "$.conditions"); diag == nil {
+			t.Error("Expected ValidateRules to report the cycle")
+		}
+		for _, engine := range engines {
+			if _, err := engine.Evaluate(gre.NewAlmanac()); err == nil {
+				t.Error("Expected error for a rule cycle")
+			}
+		}
+	})
+}
+
+func TestRuleChaining_UnknownRule(t *testing.T) {
+	engine := gre.NewEngine()
+	engine.AddRule(&gre.Rule{Name: "orphan", Conditions: gre.All(ruleRef("missing", true))})
+
+	if findDiagnostic(engine.ValidateRules(), gre.DiagnosticUnknownRule, "$.conditions") == nil {
+		t.Errorf("Expected unknown rule diagnostic, got %v", engine.ValidateRules())
+	}
+
+	res, err := engine.Evaluate(gre.NewAlmanac())
+	if err == nil {
+		t.Fatal("Expected error for an unknown rule")
+	}
+	if orphan := res.Results()["orphan"]; orphan == nil || orphan.Error == "" {
+		t.Errorf("Expected errored rule result, got %+v", orphan)
+	}
+}
+
+func TestRuleChaining_SmartSkip(t *testing.T) {
+	engine := gre.NewEngine(gre.WithSmartSkip())
+	engine.AddRules(
+		&gre.Rule{Name: "needs-fact", Conditions: gre.All(gre.Equal("missing", 1))},
+		&gre.Rule{Name: "not-skipped", Conditions: gre.All(ruleRef("needs-fact", false))},
+	)
+
+	res, err := engine.Evaluate(gre.NewAlmanac())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !res.ReduceResults()["not-skipped"] {
+		t.Errorf("Expected skipped rule to count as not fired, got %v", res.ReduceResults())
+	}
+}
+
+func TestRuleChaining_Serialization(t *testing.T) {
+	var fromJSON gre.Rule
+	err := json.Unmarshal([]byte(`{"name": "block", "conditions": {"all": [{"rule": "high-risk-transaction", "operator": "equal", "value": true}]}}`), &fromJSON)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	var fromYAML gre.Rule
+	err = yaml.Unmarshal([]byte("name: block\nconditions:\n  all:\n    - {rule: high-risk-transaction, operator: equal, value: true}\n"), &fromYAML)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	for _, rule := range []gre.Rule{fromJSON, fromYAML} {
+		if deps := rule.GetRequiredRules(); len(deps) != 1 || deps[0] != "high-risk-transaction" {
+			t.Errorf("Expected rule dependency, got %v", deps)
+		}
+		if facts := rule.GetRequiredFacts(); len(facts) != 0 {
+			t.Errorf("Expected no required facts, got %v", facts)
+		}
+	}
+
+	out, err := json.Marshal(fromJSON.Conditions.All[0])
+	if err != nil || string(out) != `{"rule":"high-risk-transaction","operator":"equal","value":true}` {
+		t.Errorf("Unexpected JSON: %s, %v", out, err)
+	}
+}
+
+func TestAlmanac_RuleResults(t *testing.T) {
+	almanac := gre.NewAlmanac()
+	if _, ok := almanac.GetRuleResult("r"); ok {
+		t.Error("Expected no result before evaluation")
+	}
+	almanac.SetRuleResult("r", true)
+	if result, ok := almanac.GetRuleResult("r"); !ok || !result {
+		t.Error("Expected stored rule result")
+	}
+
+	var empty gre.Almanac
+	empty.SetRuleResult("r", false)
+	if _, ok := empty.GetRuleResult("r"); !ok {
+		t.Error("Expected rule result on a zero almanac")
+	}
+
+	cond := gre.Condition{Rule: "r", Fact: "f", Operator: gre.OperatorEqual, Value: true}
+	diags := gre.ValidateRule(&gre.Rule{Name: "x", Conditions: gre.All(&cond)})
+	if findDiagnostic(diags, gre.DiagnosticAmbiguousCondition, "$.conditions.all[0]") == nil {
+		t.Errorf("Expected ambiguous condition warning, got %v", diags)
+	}
+}
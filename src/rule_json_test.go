@@ -0,0 +1,160 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// normalizeJSON decodes JSON into generic values so documents can be compared
+// regardless of key order and whitespace.
+func normalizeJSON(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	return v
+}
+
+func TestRuleJSON_GoldenRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../docs/examples/*/*.json")
+	if err != nil {
+		t.Fatalf("Failed to list example files: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("Expected JSON files in docs/examples")
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			golden, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", file, err)
+			}
+
+			var rules []*gre.Rule
+			if err := json.Unmarshal(golden, &rules); err != nil {
+				t.Fatalf("Failed to unmarshal rules: %v", err)
+			}
+
+			out, err := json.Marshal(rules)
+			if err != nil {
+				t.Fatalf("Failed to marshal rules: %v", err)
+			}
+
+			if !reflect.DeepEqual(normalizeJSON(t, golden), normalizeJSON(t, out)) {
+				t.Errorf("Round-trip mismatch for %s\nGolden: %s\nGot:    %s", file, golden, out)
+			}
+		})
+	}
+}
+
+func TestRuleJSON_BuilderRoundTrip(t *testing.T) {
+	rule := gre.NewRuleBuilder().
+		WithName("fraud-check").
+		WithPriority(50).
+		WithConditions(gre.ConditionNode{
+			SubSet: &gre.ConditionSet{
+				All: []gre.ConditionNode{
+					{Condition: gre.GreaterThan("amount", 1000.0)},
+					{SubSet: &gre.ConditionSet{
+						Any: []gre.ConditionNode{
+							{Condition: gre.In("country", []interface{}{"XX", "YY"})},
+							{Condition: &gre.Condition{Fact: "user", Path: "$.flags.risky", Operator: gre.OperatorEqual, Value: true}},
+						},
+					}},
+				},
+			},
+		}).
+		WithOnSuccess("block").
+		WithOnFailureEvent(gre.RuleEvent{Name: "log", Params: map[string]interface{}{"level": "info"}}).
+		Build()
+	rule.Result = true
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("Failed to marshal rule: %v", err)
+	}
+
+	expected := `{
+		"name": "fraud-check",
+		"priority": 50,
+		"conditions": {
+			"all": [
+				{"fact": "amount", "operator": "greater_than", "value": 1000},
+				{"any": [
+					{"fact": "country", "operator": "in", "value": ["XX", "YY"]},
+					{"fact": "user", "path": "$.flags.risky", "operator": "equal", "value": true}
+				]}
+			]
+		},
+		"onSuccess": ["block"],
+		"onFailure": [{"name": "log", "params": {"level": "info"}}]
+	}`
+	if !reflect.DeepEqual(normalizeJSON(t, []byte(expected)), normalizeJSON(t, data)) {
+		t.Errorf("Unexpected JSON:\nExpected: %s\nGot:      %s", expected, data)
+	}
+
+	var decoded gre.Rule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal rule: %v", err)
+	}
+
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("Failed to marshal decoded rule: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("Round-trip is not stable:\nFirst:  %s\nSecond: %s", data, again)
+	}
+
+	// The decoded rule evaluates like the original
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("amount", 5000)
+	almanac.AddFact("country", "FR")
+	almanac.AddFact("user", map[string]interface{}{"flags": map[string]interface{}{"risky": true}})
+
+	res, err := decoded.Conditions.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Failed to evaluate decoded rule: %v", err)
+	}
+	if !res.Result {
+		t.Error("Expected decoded rule to match")
+	}
+}
+
+func TestRuleEvent_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    gre.RuleEvent
+		expected string
+	}{
+		{"name only", gre.RuleEvent{Name: "notify"}, `"notify"`},
+		{"empty params", gre.RuleEvent{Name: "notify", Params: map[string]interface{}{}}, `"notify"`},
+		{"with params", gre.RuleEvent{Name: "notify", Params: map[string]interface{}{"to": "ops"}}, `{"name":"notify","params":{"to":"ops"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.event)
+			if err != nil {
+				t.Fatalf("Failed to marshal event: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestConditionNode_MarshalJSON_Empty(t *testing.T) {
+	_, err := json.Marshal(gre.ConditionNode{})
+	if err == nil {
+		t.Fatal("Expected error when marshaling an empty node")
+	}
+}
@@ -0,0 +1,285 @@
+package gorulesengine
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML implements custom YAML unmarshaling for ConditionNode.
+// Like UnmarshalJSON, a mapping with a "quantifier" is read as a QuantifierCondition,
+// one with a non-empty "fact" or "rule" as a Condition, anything else as a nested ConditionSet.
+func (n *ConditionNode) UnmarshalYAML(node *yaml.Node) error {
+	if yamlMappingValue(node, "quantifier") != nil {
+		var quantifier QuantifierCondition
+		if err := node.Decode(&quantifier); err != nil {
+			return &RuleEngineError{
+				Type: ErrYAML,
+				Msg:  fmt.Sprintf("failed to unmarshal ConditionNode at line %d, column %d", node.Line, node.Column),
+				Err:  err,
+			}
+		}
+		n.Quantifier = &quantifier
+		return nil
+	}
+
+	var cond Condition
+	err1 := node.Decode(&cond)
+	if err1 == nil && (cond.Fact != "" || cond.Rule != "") {
+		n.Condition = &cond
+		return nil
+	}
+
+	var subset ConditionSet
+	err2 := node.Decode(&subset)
+	if err2 == nil {
+		n.SubSet = &subset
+		return nil
+	}
+
+	return &RuleEngineError{
+		Type: ErrYAML,
+		Msg:  fmt.Sprintf("failed to unmarshal ConditionNode at line %d, column %d", node.Line, node.Column),
+		Err:  fmt.Errorf("errors: %v, %v", err1, err2),
+	}
+}
+
+// MarshalYAML implements custom YAML marshaling for ConditionNode.
+// A node is written as its Condition, SubSet or Quantifier directly, mirroring MarshalJSON.
+func (n ConditionNode) MarshalYAML() (interface{}, error) {
+	if n.Condition != nil {
+		return n.Condition, nil
+	}
+	if n.SubSet != nil {
+		return n.SubSet, nil
+	}
+	if n.Quantifier != nil {
+		return n.Quantifier, nil
+	}
+
+	return nil, &RuleEngineError{
+		Type: ErrYAML,
+		Msg:  "failed to marshal ConditionNode",
+		Err:  fmt.Errorf("invalid condition node: neither condition nor subset is defined"),
+	}
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for Condition.
+// The value and params are decoded with JSON semantics (numbers as float64,
+// timestamps as strings), so a rule behaves the same whichever format it was read from.
+func (c *Condition) UnmarshalYAML(node *yaml.Node) error {
+	type Alias Condition
+	var aux Alias
+	if err := node.Decode(&aux); err != nil {
+		return err
+	}
+
+	value, err := yamlNodeValue(yamlMappingValue(node, "value"))
+	if err != nil {
+		return err
+	}
+	params, err := yamlParams(yamlMappingValue(node, "params"))
+	if err != nil {
+		return err
+	}
+
+	*c = Condition(aux)
+	c.Value = value
+	c.Params = params
+	return nil
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for RuleEvent.
+// It supports both a simple string (event name) or a full mapping with parameters.
+func (re *RuleEvent) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&re.Name)
+	}
+
+	type Alias RuleEvent
+	var aux Alias
+	if err := node.Decode(&aux); err != nil {
+		return err
+	}
+	params, err := yamlParams(yamlMappingValue(node, "params"))
+	if err != nil {
+		return err
+	}
+
+	re.Name = aux.Name
+	re.Params = params
+	return nil
+}
+
+// MarshalYAML implements custom YAML marshaling for RuleEvent.
+// Events without parameters are written in the short string form, mirroring MarshalJSON.
+func (re RuleEvent) MarshalYAML() (interface{}, error) {
+	if len(re.Params) == 0 {
+		return re.Name, nil
+	}
+
+	type Alias RuleEvent
+	return Alias(re), nil
+}
+
+// yamlMappingValue returns the value node of key in a mapping node, or nil.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlParams decodes a params mapping with JSON semantics.
+func yamlParams(node *yaml.Node) (map[string]interface{}, error) {
+	value, err := yamlNodeValue(node)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	params, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, &RuleEngineError{
+			Type: ErrYAML,
+			Msg:  fmt.Sprintf("params at line %d, column %d must be a mapping", node.Line, node.Column),
+		}
+	}
+	return params, nil
+}
+
+// yamlNodeValue decodes a node into the generic values encoding/json would produce:
+// float64 numbers, map[string]interface{} mappings and []interface{} sequences.
+// Timestamps keep their original text.
+func yamlNodeValue(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlNodeValue(node.Alias)
+
+	case yaml.MappingNode:
+		var entries map[string]yaml.Node
+		if err := node.Decode(&entries); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(entries))
+		for key, entry := range entries {
+			v, err := yamlNodeValue(&entry)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+
+	case yaml.SequenceNode:
+		s := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			v, err := yamlNodeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = v
+		}
+		return s, nil
+	}
+
+	switch node.ShortTag() {
+	case "!!int", "!!float":
+		var f float64
+		err := node.Decode(&f)
+		return f, err
+	case "!!timestamp":
+		return node.Value, nil
+	}
+
+	var v interface{}
+	err := node.Decode(&v)
+	return v, err
+}
+
+// decodeYAMLRules decodes a YAML document holding a single rule or a sequence of rules.
+// Errors are located at the reported YAML line, or at the start of the offending rule.
+func decodeYAMLRules(data []byte) ([]*Rule, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, newYAMLParseError(data, err, 0, 0)
+	}
+	if len(doc.Content) == 0 {
+		return nil, newYAMLParseError(data, errors.New("empty rule file"), 1, 1)
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		var rule Rule
+		if err := root.Decode(&rule); err != nil {
+			return nil, newYAMLParseError(data, err, root.Line, root.Column)
+		}
+		return []*Rule{&rule}, nil
+	}
+
+	rules := make([]*Rule, 0, len(root.Content))
+	for i, node := range root.Content {
+		var rule Rule
+		if err := node.Decode(&rule); err != nil {
+			return nil, newYAMLParseError(data, fmt.Errorf("rule #%d: %w", i, err), node.Line, node.Column)
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
+
+// yamlPositionPattern matches the positions reported by yaml.v3 and by the UnmarshalYAML methods.
+var yamlPositionPattern = regexp.MustCompile(`line (\d+)(?:, column (\d+))?`)
+
+// newYAMLParseError creates a FileParseError at the most specific position found in
+// the error message (the deepest line, with a column if known), falling back to the
+// given line and column. The column is zero when only the line is known.
+func newYAMLParseError(data []byte, err error, line, column int) *FileParseError {
+	if matches := yamlPositionPattern.FindAllStringSubmatch(err.Error(), -1); len(matches) > 0 {
+		line, column = 0, 0
+		for _, match := range matches {
+			l, _ := strconv.Atoi(match[1])
+			c, _ := strconv.Atoi(match[2])
+			if l > line || (l == line && c > column) {
+				line, column = l, c
+			}
+		}
+	}
+
+	return &FileParseError{
+		Line:   line,
+		Column: column,
+		Offset: lineColumnOffset(data, line, column),
+		Err:    err,
+	}
+}
+
+// lineColumnOffset returns the byte offset of a 1-based line and column in data.
+func lineColumnOffset(data []byte, line, column int) int64 {
+	if line < 1 {
+		return 0
+	}
+	offset := 0
+	for l := 1; l < line && offset < len(data); offset++ {
+		if data[offset] == '\n' {
+			l++
+		}
+	}
+	if column > 1 {
+		offset += column - 1
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return int64(offset)
+}
@@ -0,0 +1,173 @@
+package gorulesengine_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+	"gopkg.in/yaml.v3"
+)
+
+const rulesYAML = `
+- name: fraud-check
+  priority: 50
+  conditions:
+    all:
+      - fact: amount
+        operator: greater_than
+        value: 1000
+      - any:
+          - fact: country
+            operator: in
+            value: [XX, YY]
+          - fact: user
+            path: $.flags.risky
+            operator: equal
+            value: true
+          - fact: signup
+            operator: equal
+            value: 2024-01-31
+            params: {window: 7}
+  onSuccess: [block]
+  onFailure:
+    - name: log
+      params:
+        level: info
+        retries: 3
+`
+
+const rulesYAMLAsJSON = `[{
+	"name": "fraud-check",
+	"priority": 50,
+	"conditions": {
+		"all": [
+			{"fact": "amount", "operator": "greater_than", "value": 1000},
+			{"any": [
+				{"fact": "country", "operator": "in", "value": ["XX", "YY"]},
+				{"fact": "user", "path": "$.flags.risky", "operator": "equal", "value": true},
+				{"fact": "signup", "operator": "equal", "value": "2024-01-31", "params": {"window": 7}}
+			]}
+		]
+	},
+	"onSuccess": ["block"],
+	"onFailure": [{"name": "log", "params": {"level": "info", "retries": 3}}]
+}]`
+
+func TestRuleYAML_SameSchemaAsJSON(t *testing.T) {
+	var fromYAML []*gre.Rule
+	if err := yaml.Unmarshal([]byte(rulesYAML), &fromYAML); err != nil {
+		t.Fatalf("Failed to unmarshal YAML rules: %v", err)
+	}
+	var fromJSON []*gre.Rule
+	if err := json.Unmarshal([]byte(rulesYAMLAsJSON), &fromJSON); err != nil {
+		t.Fatalf("Failed to unmarshal JSON rules: %v", err)
+	}
+
+	// Values are decoded with the same Go types, whatever the format
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		yamlOut, _ := json.Marshal(fromYAML)
+		jsonOut, _ := json.Marshal(fromJSON)
+		t.Errorf("YAML and JSON rules differ\nYAML: %s\nJSON: %s", yamlOut, jsonOut)
+	}
+	if amount := fromYAML[0].Conditions.All[0].Condition.Value; amount != 1000.0 {
+		t.Errorf("Expected float64 value, got %T %v", amount, amount)
+	}
+}
+
+func TestRuleYAML_RoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../docs/examples/*/*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Expected JSON files in docs/examples, got %v", err)
+	}
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			golden, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", file, err)
+			}
+			var rules []*gre.Rule
+			if err := json.Unmarshal(golden, &rules); err != nil {
+				t.Fatalf("Failed to unmarshal rules: %v", err)
+			}
+
+			out, err := yaml.Marshal(rules)
+			if err != nil {
+				t.Fatalf("Failed to marshal YAML: %v", err)
+			}
+			var decoded []*gre.Rule
+			if err := yaml.Unmarshal(out, &decoded); err != nil {
+				t.Fatalf("Failed to unmarshal YAML: %v\n%s", err, out)
+			}
+
+			again, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatalf("Failed to marshal JSON: %v", err)
+			}
+			if !reflect.DeepEqual(normalizeJSON(t, golden), normalizeJSON(t, again)) {
+				t.Errorf("Round-trip mismatch\nGolden: %s\nYAML:   %s\nGot:    %s", golden, out, again)
+			}
+		})
+	}
+
+	out, err := yaml.Marshal(gre.RuleEvent{Name: "notify"})
+	if err != nil || strings.TrimSpace(string(out)) != "notify" {
+		t.Errorf("Expected event shorthand, got %q, %v", out, err)
+	}
+	if _, err := yaml.Marshal(gre.ConditionNode{}); err == nil {
+		t.Error("Expected error when marshaling an empty node")
+	}
+}
+
+func TestRuleYAML_FileProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"rules/a.yaml": {Data: []byte(rulesYAML)},
+		"rules/b.yml":  {Data: []byte("name: single\nconditions:\n  all:\n    - {fact: age, operator: greater_than, value: 18}\n")},
+		"rules/c.json": {Data: []byte(`{"name": "json", "conditions": {"all": [{"fact": "age", "operator": "less_than", "value": 10}]}}`)},
+	}
+
+	rules, err := gre.NewFSDirRuleProvider(fsys, "rules").FetchRules(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rules) != 3 || rules[0].Name != "fraud-check" || rules[1].Name != "single" || rules[2].Name != "json" {
+		t.Errorf("Expected YAML and JSON rules, got %d rules", len(rules))
+	}
+}
+
+func TestRuleYAML_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+	}{
+		{"syntax error", "- name: r1\n  conditions: {all: [\n- name: r2\n", 2, 0},
+		{"type error", "name: r1\npriority: high\n", 2, 0},
+		{"invalid node", "- name: r1\n- name: r2\n  conditions:\n    all:\n      - 42\n", 5, 9},
+		{"invalid params", "name: r1\nonSuccess:\n  - name: e\n    params: [1]\n", 4, 0},
+		{"empty file", "\n", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"rules.yaml": {Data: []byte(tt.content)}}
+			_, err := gre.NewFSRuleProvider(fsys, "rules.yaml").FetchRules(context.Background())
+
+			var parseErr *gre.FileParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected FileParseError, got %v", err)
+			}
+			if parseErr.File != "rules.yaml" || parseErr.Line != tt.line || parseErr.Column != tt.column {
+				t.Errorf("Expected rules.yaml:%d:%d, got %s:%d:%d (%v)", tt.line, tt.column, parseErr.File, parseErr.Line, parseErr.Column, parseErr)
+			}
+		})
+	}
+}
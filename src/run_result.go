@@ -0,0 +1,252 @@
+package gorulesengine
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RunResult holds the outcome of a single engine evaluation: the rule results,
+// the almanac used, timings and the error (if any) that ended the run.
+// A RunResult is owned by the caller and is never touched by other runs, which
+// makes it safe to use when one Engine is shared across goroutines.
+type RunResult struct {
+	results   map[string]*RuleResult
+	almanac   *Almanac
+	startedAt time.Time
+	duration  time.Duration
+	err       error
+
+	iterations    int              // Number of evaluation passes
+	assertions    []FactAssertion  // Facts asserted during forward chaining
+	policy        HitPolicy        // Hit policy used by GenerateResponse
+	resolveParams bool             // Whether GenerateResponse resolves event params
+	haltedBy      string           // Rule after which the run halted
+	errs          []error          // Errors collected when continuing on error
+	events        []EventExecution // Outcomes of the sync events fired during the run
+
+	simulated       bool             // Whether the run was a simulation
+	simulatedEvents []SimulatedEvent // Events a simulated run would have dispatched
+}
+
+// newRunResult creates an empty RunResult bound to the given almanac.
+func newRunResult(almanac *Almanac) *RunResult {
+	return &RunResult{
+		results:    make(map[string]*RuleResult),
+		almanac:    almanac,
+		startedAt:  time.Now(),
+		iterations: 1,
+	}
+}
+
+// Results returns the detailed results of rule evaluations for this run.
+// If the run was aborted, only the rules processed before the error are present.
+func (r *RunResult) Results() map[string]*RuleResult {
+	return r.results
+}
+
+// ReduceResults converts the detailed RuleResults of this run to a simple map of booleans.
+// Rules not evaluated because the run halted are left out.
+func (r *RunResult) ReduceResults() map[string]bool {
+	reduced := make(map[string]bool, len(r.results))
+	for name, res := range r.results {
+		if res.Status == RuleStatusNotEvaluated {
+			continue
+		}
+		reduced[name] = res.Result
+	}
+	return reduced
+}
+
+// Almanac returns the almanac the run was evaluated against.
+func (r *RunResult) Almanac() *Almanac {
+	return r.almanac
+}
+
+// StartedAt returns the time at which the run started.
+func (r *RunResult) StartedAt() time.Time {
+	return r.startedAt
+}
+
+// Duration returns the total duration of the run.
+func (r *RunResult) Duration() time.Duration {
+	return r.duration
+}
+
+// Iterations returns the number of evaluation passes of the run: 1, plus one per
+// forward chaining pass re-evaluating the rules affected by asserted facts.
+func (r *RunResult) Iterations() int {
+	return r.iterations
+}
+
+// Assertions returns the audit trail of the facts asserted during the run, in
+// order, with the rule that asserted them. It is only recorded with WithForwardChaining.
+func (r *RunResult) Assertions() []FactAssertion {
+	return r.assertions
+}
+
+// EventExecutions returns the outcomes of the sync events fired during the run,
+// in firing order, with their attempts and errors. Async events are reported
+// through OnAsyncError and the metrics collector instead.
+func (r *RunResult) EventExecutions() []EventExecution {
+	return r.events
+}
+
+// Simulated reports whether the run was a simulation (see Engine.Simulate).
+func (r *RunResult) Simulated() bool {
+	return r.simulated
+}
+
+// SimulatedEvents returns the events a simulated run would have dispatched, in
+// order, with their merged and resolved params. It is nil for real runs.
+func (r *RunResult) SimulatedEvents() []SimulatedEvent {
+	return r.simulatedEvents
+}
+
+// HaltedBy returns the name of the rule after which the run halted (through
+// StopProcessing, WithStopOnFirstMatch or EventContext.Halt), or "" if it did not halt.
+func (r *RunResult) HaltedBy() string {
+	return r.haltedBy
+}
+
+// halt records that the run stopped after rule, marking the remaining rules as not evaluated.
+func (r *RunResult) halt(rule *Rule, remaining []*Rule) {
+	r.haltedBy = rule.Name
+	for _, next := range remaining {
+		r.results[next.Name] = newSkippedRuleResult(next, RuleStatusNotEvaluated)
+	}
+}
+
+// Err returns the error that aborted the run, or nil if it completed.
+func (r *RunResult) Err() error {
+	return r.err
+}
+
+// GenerateResponse builds the formatted Response object for JSON Marshalling,
+// deciding the outcome with the hit policy of the engine (AnyMatchPolicy by default).
+// If the policy cannot decide, the decision is declined and EngineResponse.Error tells why.
+func (r *RunResult) GenerateResponse() *EngineResponse {
+	res, _ := r.GenerateResponseWithPolicy(r.policy)
+	return res
+}
+
+// GenerateResponseWithPolicy is like GenerateResponse but decides the outcome of
+// this run with the given hit policy (AnyMatchPolicy if nil). It also returns the
+// error of a policy that cannot decide.
+//
+// Rules are considered by descending priority, then by name, so the response is
+// deterministic. Events are listed in that order; the reason is taken from the
+// first deciding rule, or from the first rule if none decided.
+func (r *RunResult) GenerateResponseWithPolicy(policy HitPolicy) (*EngineResponse, error) {
+	if policy == nil {
+		policy = AnyMatchPolicy()
+	}
+
+	res := &EngineResponse{
+		Decision:      DecisionDecline,
+		Policy:        policy.Name(),
+		DecidingRules: []string{},
+		Events:        []EventResponse{},
+		Metadata:      make(map[string]interface{}),
+	}
+
+	if len(r.results) == 0 {
+		return res, nil
+	}
+
+	// Extract metadata from Almanac if available
+	if r.almanac != nil {
+		facts := r.almanac.GetFacts()
+		for factID, fact := range facts {
+			meta := fact.Metadata()
+			if len(meta) > 0 {
+				res.Metadata[string(factID)] = meta
+			}
+		}
+	}
+
+	results := sortedRuleResults(r.results)
+
+	decision, err := policy.Decide(results)
+	if decision.Decision != "" {
+		res.Decision = decision.Decision
+	}
+	if decision.Rules != nil {
+		res.DecidingRules = decision.Rules
+	}
+	res.Score = decision.Score
+	if err != nil {
+		res.Decision = DecisionDecline
+		res.Error = err.Error()
+	}
+
+	for _, result := range results {
+		// Skipped, halted and errored rules fired no events
+		if result.Status != "" {
+			continue
+		}
+		events := result.OnFailure
+		if result.Result {
+			events = result.OnSuccess
+		}
+		for _, ev := range events {
+			res.Events = append(res.Events, r.eventResponse(ev))
+		}
+	}
+
+	// The first deciding rule (or the first rule) explains the result
+	var primaryResult *RuleResult
+	if len(res.DecidingRules) > 0 {
+		primaryResult = r.results[res.DecidingRules[0]]
+	} else if len(results) > 0 {
+		primaryResult = results[0]
+	}
+	if primaryResult != nil {
+		if primaryResult.Conditions != nil {
+			res.Reason = primaryResult.Conditions
+		} else {
+			res.Reason = fmt.Sprintf("Rule '%s' determined the result", primaryResult.Name)
+		}
+	}
+
+	return res, err
+}
+
+// decision returns the decision of the hit policy of the run, as GenerateResponse.
+func (r *RunResult) decision() string {
+	policy := r.policy
+	if policy == nil {
+		policy = AnyMatchPolicy()
+	}
+	if len(r.results) == 0 {
+		return DecisionDecline
+	}
+
+	decision, err := policy.Decide(sortedRuleResults(r.results))
+	if err != nil || decision.Decision == "" {
+		return DecisionDecline
+	}
+	return decision.Decision
+}
+
+// eventResponse builds the EventResponse of an event. With event param resolution,
+// its params are resolved against the almanac of the run; if they cannot be
+// resolved, the params are left as is and EventResponse.Error tells why.
+func (r *RunResult) eventResponse(ev RuleEvent) EventResponse {
+	if !r.resolveParams {
+		return EventResponse{Type: ev.Name, Params: ev.Params}
+	}
+	params, err := resolveEventParams(context.Background(), r.almanac, ev.Params)
+	if err != nil {
+		return EventResponse{
+			Type:   ev.Name,
+			Params: ev.Params,
+			Error: (&RuleEngineError{
+				Type: ErrEvent,
+				Msg:  fmt.Sprintf("cannot resolve the params of event '%s'", ev.Name),
+				Err:  err,
+			}).Error(),
+		}
+	}
+	return EventResponse{Type: ev.Name, Params: params}
+}
@@ -0,0 +1,181 @@
+package gorulesengine_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+func newAdultRule() *gre.Rule {
+	return &gre.Rule{
+		Name:     "adult",
+		Priority: 10,
+		Conditions: gre.ConditionSet{
+			All: []gre.ConditionNode{
+				{Condition: gre.GreaterThanInclusive("age", 18)},
+			},
+		},
+		OnSuccess: []gre.RuleEvent{{Name: "allow"}},
+		OnFailure: []gre.RuleEvent{{Name: "deny"}},
+	}
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	t.Run("returns a run result owning the outcome", func(t *testing.T) {
+		engine := gre.NewEngine(gre.WithAuditTrace())
+		engine.AddRule(newAdultRule())
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("age", 25)
+
+		res, err := engine.Evaluate(almanac)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if res.Err() != nil {
+			t.Errorf("Expected no run error, got: %v", res.Err())
+		}
+		if res.Almanac() != almanac {
+			t.Error("Expected run result to reference the evaluated almanac")
+		}
+		if res.StartedAt().IsZero() {
+			t.Error("Expected start time to be set")
+		}
+		if res.Duration() <= 0 {
+			t.Error("Expected duration to be recorded")
+		}
+		if !res.ReduceResults()["adult"] {
+			t.Error("Expected rule 'adult' to succeed")
+		}
+		if res.Results()["adult"].Conditions == nil {
+			t.Error("Expected audit trace in rule result")
+		}
+
+		response := res.GenerateResponse()
+		if response.Decision != gre.DecisionAuthorize {
+			t.Errorf("Expected decision %s, got %s", gre.DecisionAuthorize, response.Decision)
+		}
+		if len(response.Events) != 1 || response.Events[0].Type != "allow" {
+			t.Errorf("Expected event 'allow', got %v", response.Events)
+		}
+	})
+
+	t.Run("does not modify engine results", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.AddRule(newAdultRule())
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("age", 25)
+
+		if _, err := engine.Evaluate(almanac); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(engine.Results()) != 0 {
+			t.Errorf("Expected engine results to be untouched, got %v", engine.Results())
+		}
+	})
+
+	t.Run("returns partial results on error", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.SetEventHandler(&MockEventHandler{})
+		engine.AddRule(newAdultRule())
+		engine.AddRule(&gre.Rule{
+			Name:     "broken",
+			Priority: 1,
+			Conditions: gre.ConditionSet{
+				All: []gre.ConditionNode{
+					{Condition: &gre.Condition{Fact: "age", Operator: "unknown", Value: 1}},
+				},
+			},
+		})
+		engine.RegisterEvents(gre.Event{Name: "allow"}, gre.Event{Name: "deny"})
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("age", 25)
+
+		res, err := engine.Evaluate(almanac)
+		if err == nil {
+			t.Fatal("Expected error for unknown operator")
+		}
+		if !errors.Is(res.Err(), err) {
+			t.Errorf("Expected run result to hold the error, got %v", res.Err())
+		}
+		if _, ok := res.Results()["adult"]; !ok {
+			t.Error("Expected rule processed before the error to be present")
+		}
+		broken, ok := res.Results()["broken"]
+		if !ok {
+			t.Fatal("Expected failing rule to be reported")
+		}
+		if broken.Result || broken.Error == "" {
+			t.Errorf("Expected failing rule to be false with an error, got %+v", broken)
+		}
+	})
+
+	t.Run("run stores the outcome on the engine", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.AddRule(newAdultRule())
+
+		almanac := gre.NewAlmanac()
+		almanac.AddFact("age", 12)
+
+		e, err := engine.Run(almanac)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if e.ReduceResults()["adult"] {
+			t.Error("Expected rule 'adult' to fail")
+		}
+		if e.GenerateResponse().Decision != gre.DecisionDecline {
+			t.Error("Expected decline decision")
+		}
+	})
+}
+
+func TestEngineEvaluate_ConcurrentRuns(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%v", parallel), func(t *testing.T) {
+			opts := []gre.EngineOption{gre.WithAuditTrace()}
+			if parallel {
+				opts = append(opts, gre.WithParallelExecution(4))
+			}
+			engine := gre.NewEngine(opts...)
+			engine.AddRule(newAdultRule())
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 100)
+			for i := 0; i < 100; i++ {
+				wg.Add(1)
+				go func(age int) {
+					defer wg.Done()
+					almanac := gre.NewAlmanac()
+					almanac.AddFact("age", age)
+
+					res, err := engine.Evaluate(almanac)
+					if err != nil {
+						errs <- err
+						return
+					}
+
+					expected := age >= 18
+					if got := res.ReduceResults()["adult"]; got != expected {
+						errs <- fmt.Errorf("age %d: expected %v, got %v", age, expected, got)
+					}
+					factValue := res.Results()["adult"].Conditions.Results[0].Condition.FactValue
+					if factValue != age {
+						errs <- fmt.Errorf("age %d: audit trace reports fact value %v", age, factValue)
+					}
+				}(i % 36)
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
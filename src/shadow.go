@@ -0,0 +1,163 @@
+package gorulesengine
+
+import (
+	"context"
+	"sort"
+)
+
+// ShadowComparison compares the outcome of the active rules and of the shadow
+// rules for one run. Only the active rules fire events.
+type ShadowComparison struct {
+	ActiveDecision  string     `json:"activeDecision"`
+	ShadowDecision  string     `json:"shadowDecision"`
+	DecisionChanged bool       `json:"decisionChanged"`
+	FlippedRules    []RuleFlip `json:"flippedRules,omitempty"` // Rules of both sets whose outcome differs, by name
+	ActiveOnly      []string   `json:"activeOnly,omitempty"`   // Rules with an outcome in the active run only
+	ShadowOnly      []string   `json:"shadowOnly,omitempty"`   // Rules with an outcome in the shadow run only
+	ActiveError     string     `json:"activeError,omitempty"`
+	ShadowError     string     `json:"shadowError,omitempty"`
+
+	Active *RunResult `json:"-"` // Outcome of the active rules
+	Shadow *RunResult `json:"-"` // Outcome of the shadow rules, a simulation holding the events they would have fired
+}
+
+// RuleFlip is a rule whose outcome differs between the active and the shadow rules.
+type RuleFlip struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Shadow bool   `json:"shadow"`
+}
+
+// Diverged reports whether the shadow rules decided or matched differently.
+func (c ShadowComparison) Diverged() bool {
+	return c.DecisionChanged || len(c.FlippedRules) > 0 || len(c.ActiveOnly) > 0 || len(c.ShadowOnly) > 0
+}
+
+// ShadowComparisonCollector can be implemented by a MetricsCollector to observe
+// the comparisons of the shadow and active rules, e.g. to track divergence rates.
+type ShadowComparisonCollector interface {
+	ObserveShadowComparison(comparison ShadowComparison)
+}
+
+// SetShadowRules sets candidate rules evaluated in shadow of the active rules.
+// After each run (but not a simulation), the shadow rules are simulated on a
+// copy of the almanac taken before the run, so they see the same facts without
+// firing events or affecting the almanac, and the outcomes are compared. The
+// comparison is passed to OnShadowComparison and to a metrics collector
+// implementing ShadowComparisonCollector. Shadow rules are only evaluated when
+// one of these observes the comparison, and they add to the duration of the run.
+//
+// The rules are compiled and validated; an invalid set or a dependency cycle is
+// rejected with a ValidationError. Setting no rules stops the shadow evaluation.
+func (e *Engine) SetShadowRules(rules []*Rule) error {
+	if err := validateRuleSet(rules); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shadowRules = rules
+	return nil
+}
+
+// ShadowRules returns the rules evaluated in shadow of the active rules.
+func (e *Engine) ShadowRules() []*Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.shadowRules
+}
+
+// OnShadowComparison sets a callback receiving the comparison of the shadow and
+// active rules after each run. It is called synchronously, before the run returns.
+func (e *Engine) OnShadowComparison(callback func(ShadowComparison)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onShadowComparison = callback
+}
+
+// shadowSet is the snapshot of the shadow rules of a run.
+type shadowSet struct {
+	rules        []*Rule
+	onComparison func(ShadowComparison)
+	collector    ShadowComparisonCollector
+}
+
+// shadowSnapshot returns the shadow rules to evaluate for a run, or nil if there
+// are none or nobody observes the comparison. The caller must hold the engine lock.
+func (e *Engine) shadowSnapshot() *shadowSet {
+	if len(e.shadowRules) == 0 {
+		return nil
+	}
+	collector, _ := e.metrics.(ShadowComparisonCollector)
+	if e.onShadowComparison == nil && collector == nil {
+		return nil
+	}
+	return &shadowSet{
+		rules:        e.shadowRules,
+		onComparison: e.onShadowComparison,
+		collector:    collector,
+	}
+}
+
+// compareShadow simulates the shadow rules on almanac and reports their comparison
+// with the active run. The dynamic facts computed by the active run are reused.
+// Nothing is reported if ctx is done.
+func (e *Engine) compareShadow(ctx context.Context, shadow *shadowSet, active *RunResult, almanac *Almanac, options map[string]interface{}) {
+	if ctx.Err() != nil {
+		return
+	}
+	almanac.seedComputedFacts(active.almanac)
+
+	shadowCtx, _ := withSimulation(ctx)
+	shadowRun, _ := e.evaluate(shadowCtx, almanac, shadow.rules, options, nil)
+	if ctx.Err() != nil {
+		return
+	}
+
+	comparison := newShadowComparison(active, shadowRun)
+	if shadow.collector != nil {
+		shadow.collector.ObserveShadowComparison(comparison)
+	}
+	if shadow.onComparison != nil {
+		shadow.onComparison(comparison)
+	}
+}
+
+// newShadowComparison compares the outcomes of an active and a shadow run.
+func newShadowComparison(active, shadow *RunResult) ShadowComparison {
+	c := ShadowComparison{
+		ActiveDecision: active.decision(),
+		ShadowDecision: shadow.decision(),
+		Active:         active,
+		Shadow:         shadow,
+	}
+	c.DecisionChanged = c.ActiveDecision != c.ShadowDecision
+	if active.err != nil {
+		c.ActiveError = active.err.Error()
+	}
+	if shadow.err != nil {
+		c.ShadowError = shadow.err.Error()
+	}
+
+	activeResults := active.ReduceResults()
+	shadowResults := shadow.ReduceResults()
+	for name, activeResult := range activeResults {
+		shadowResult, ok := shadowResults[name]
+		switch {
+		case !ok:
+			c.ActiveOnly = append(c.ActiveOnly, name)
+		case shadowResult != activeResult:
+			c.FlippedRules = append(c.FlippedRules, RuleFlip{Name: name, Active: activeResult, Shadow: shadowResult})
+		}
+	}
+	for name := range shadowResults {
+		if _, ok := activeResults[name]; !ok {
+			c.ShadowOnly = append(c.ShadowOnly, name)
+		}
+	}
+
+	sort.Slice(c.FlippedRules, func(i, j int) bool { return c.FlippedRules[i].Name < c.FlippedRules[j].Name })
+	sort.Strings(c.ActiveOnly)
+	sort.Strings(c.ShadowOnly)
+	return c
+}
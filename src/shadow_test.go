@@ -0,0 +1,178 @@
+package gorulesengine_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// shadowMetrics is a MetricsCollector counting the shadow comparisons.
+type shadowMetrics struct {
+	*MockMetricsCollector
+	mu       sync.Mutex
+	compared int
+	diverged int
+}
+
+func (m *shadowMetrics) ObserveShadowComparison(comparison gre.ShadowComparison) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.compared++
+	if comparison.Diverged() {
+		m.diverged++
+	}
+}
+
+func newFraudRule(name string, threshold int, event string) *gre.Rule {
+	return &gre.Rule{
+		Name:       name,
+		Conditions: gre.All(gre.GreaterThan("amount", threshold)),
+		OnSuccess:  []gre.RuleEvent{{Name: event}},
+	}
+}
+
+func TestShadowRules(t *testing.T) {
+	metrics := &shadowMetrics{MockMetricsCollector: NewMockMetricsCollector()}
+	engine := gre.NewEngine(gre.WithMetrics(metrics))
+
+	var fired []string
+	engine.RegisterEvents(
+		gre.Event{Name: "block", Action: func(ctx gre.EventContext) error { fired = append(fired, ctx.RuleName); return nil }},
+		gre.Event{Name: "review", Action: func(ctx gre.EventContext) error { fired = append(fired, ctx.RuleName); return nil }},
+	)
+	engine.AddRules(newFraudRule("large-amount", 1000, "block"), newFraudRule("legacy", 5000, "review"))
+
+	err := engine.SetShadowRules([]*gre.Rule{
+		newFraudRule("large-amount", 500, "block"),
+		{
+			Name:       "flag",
+			Conditions: gre.All(gre.GreaterThan("amount", 100)),
+			OnSuccess:  []gre.RuleEvent{{Name: gre.SetFactEventName, Params: map[string]interface{}{"fact": "flagged", "value": true}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var comparisons []gre.ShadowComparison
+	engine.OnShadowComparison(func(c gre.ShadowComparison) { comparisons = append(comparisons, c) })
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("amount", 800)
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(fired) != 0 {
+		t.Errorf("Expected no event from the shadow rules, got %v", fired)
+	}
+	if value, _ := almanac.GetFactValue("flagged", nil, ""); value != nil {
+		t.Errorf("Expected the shadow rules not to assert facts in the almanac, got %v", value)
+	}
+	if len(comparisons) != 1 {
+		t.Fatalf("Expected one comparison, got %d", len(comparisons))
+	}
+
+	c := comparisons[0]
+	if c.ActiveDecision != gre.DecisionDecline || c.ShadowDecision != gre.DecisionAuthorize || !c.DecisionChanged {
+		t.Errorf("Expected the decision to change from decline to authorize, got %+v", c)
+	}
+	if expected := []gre.RuleFlip{{Name: "large-amount", Active: false, Shadow: true}}; !reflect.DeepEqual(c.FlippedRules, expected) {
+		t.Errorf("Expected flipped rules %v, got %v", expected, c.FlippedRules)
+	}
+	if !reflect.DeepEqual(c.ActiveOnly, []string{"legacy"}) || !reflect.DeepEqual(c.ShadowOnly, []string{"flag"}) {
+		t.Errorf("Unexpected rules in one set only: %v, %v", c.ActiveOnly, c.ShadowOnly)
+	}
+	if c.Active != run || !c.Shadow.Simulated() || len(c.Shadow.SimulatedEvents()) != 2 {
+		t.Errorf("Expected the active run and a simulated shadow run, got %+v", c)
+	}
+
+	// Metrics: one comparison, one divergence, and one engine run only
+	if metrics.compared != 1 || metrics.diverged != 1 {
+		t.Errorf("Expected one diverging comparison, got %d/%d", metrics.diverged, metrics.compared)
+	}
+	if metrics.EngineRunCount != 1 {
+		t.Errorf("Expected the shadow run not to be observed as an engine run, got %d", metrics.EngineRunCount)
+	}
+
+	// Simulations are not shadowed
+	if _, err := engine.Simulate(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(comparisons) != 1 {
+		t.Errorf("Expected no comparison for a simulation, got %d", len(comparisons))
+	}
+
+	// Without shadow rules, nothing is compared
+	if err := engine.SetShadowRules(nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := engine.Evaluate(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(comparisons) != 1 {
+		t.Errorf("Expected no comparison without shadow rules, got %d", len(comparisons))
+	}
+}
+
+func TestShadowRules_Rejected(t *testing.T) {
+	engine := gre.NewEngine()
+	active := []*gre.Rule{newFraudRule("large-amount", 1000, "block")}
+	if err := engine.SetShadowRules(active); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err := engine.SetShadowRules([]*gre.Rule{{Name: "broken", Conditions: gre.All(gre.Regex("email", "[a-z"))}})
+
+	var valErr *gre.ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if rules := engine.ShadowRules(); len(rules) != 1 || rules[0] != active[0] {
+		t.Errorf("Expected the previous shadow rules to be kept, got %v", rules)
+	}
+}
+
+func TestShadowRules_ReuseComputedFacts(t *testing.T) {
+	engine := gre.NewEngine()
+	engine.AddRules(
+		newFraudRule("large-amount", 1000, "block"),
+		&gre.Rule{
+			Name:       "upgrade",
+			Conditions: gre.All(gre.Equal("tier", "standard")),
+			OnSuccess:  []gre.RuleEvent{{Name: gre.SetFactEventName, Params: map[string]interface{}{"fact": "tier", "value": "gold"}}},
+		},
+	)
+	err := engine.SetShadowRules([]*gre.Rule{
+		newFraudRule("large-amount", 500, "block"),
+		{Name: "standard-tier", Conditions: gre.All(gre.Equal("tier", "standard"))},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var comparison gre.ShadowComparison
+	engine.OnShadowComparison(func(c gre.ShadowComparison) { comparison = c })
+
+	amountCalls := 0
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("amount", func() interface{} { amountCalls++; return 800 })
+	almanac.AddFact("tier", func() interface{} { return "standard" })
+
+	if _, err := engine.Evaluate(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The amount computed by the active run is reused by the shadow rules
+	if amountCalls != 1 {
+		t.Errorf("Expected the amount to be computed once, got %d", amountCalls)
+	}
+	// The tier asserted by the active run is not: the shadow rules see it as before the run
+	if results := comparison.Shadow.ReduceResults(); !results["large-amount"] || !results["standard-tier"] {
+		t.Errorf("Expected both shadow rules to match, got %v", results)
+	}
+}
@@ -0,0 +1,69 @@
+package gorulesengine
+
+import (
+	"context"
+	"sync"
+)
+
+// SimulatedEvent is an event that a simulated run would have dispatched.
+type SimulatedEvent struct {
+	EventName string                 `json:"eventName"`
+	RuleName  string                 `json:"ruleName"`
+	Result    bool                   `json:"result"`          // Outcome of the rule that fired the event
+	Async     bool                   `json:"async,omitempty"` // Whether the event would have run asynchronously
+	Params    map[string]interface{} `json:"params,omitempty"`
+}
+
+// Simulate evaluates the rules against the almanac like Evaluate, but without
+// dispatching events: no action, global handler or middleware is called. The
+// events that would have been dispatched are recorded in order, with their merged
+// and resolved params, and returned by RunResult.SimulatedEvents.
+//
+// The built-in setFact event is still applied to the almanac, so that forward
+// chaining behaves as in a real run, and it is recorded too. Since actions do
+// not run, EventContext.Halt cannot halt a simulated run.
+// Metrics are not reported for simulated runs.
+func (e *Engine) Simulate(almanac *Almanac) (*RunResult, error) {
+	return e.SimulateContext(context.Background(), almanac)
+}
+
+// SimulateContext is like Simulate but propagates ctx like EvaluateContext.
+func (e *Engine) SimulateContext(ctx context.Context, almanac *Almanac) (*RunResult, error) {
+	ctx, _ = withSimulation(ctx)
+	return e.EvaluateContext(ctx, almanac)
+}
+
+// simulation collects the events of a simulated run, carried by its context.
+type simulation struct {
+	mu     sync.Mutex
+	events []SimulatedEvent
+}
+
+// simulationKey is the context key of the simulation of a run.
+type simulationKey struct{}
+
+// withSimulation returns a context carrying a new simulation.
+func withSimulation(ctx context.Context) (context.Context, *simulation) {
+	sim := &simulation{}
+	return context.WithValue(ctx, simulationKey{}, sim), sim
+}
+
+// simulationFromContext returns the simulation carried by ctx, or nil for a real run.
+func simulationFromContext(ctx context.Context) *simulation {
+	sim, _ := ctx.Value(simulationKey{}).(*simulation)
+	return sim
+}
+
+// record appends an event to the simulation.
+func (s *simulation) record(event SimulatedEvent) {
+	s.mu.Lock()
+	s.events = append(s.events, event)
+	s.mu.Unlock()
+}
+
+// recorded returns the events recorded so far.
+func (s *simulation) recorded() []SimulatedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.events
+}
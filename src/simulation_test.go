@@ -0,0 +1,120 @@
+package gorulesengine_test
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+func newSimulationEngine(opts ...gre.EngineOption) (*gre.Engine, *atomic.Int32) {
+	engine := gre.NewEngine(append([]gre.EngineOption{gre.WithEventParamResolution()}, opts...)...)
+
+	calls := &atomic.Int32{}
+	count := func(ctx gre.EventContext) error { calls.Add(1); return nil }
+	engine.SetEventHandler(gre.EventHandlerFunc(func(event gre.Event, ctx gre.EventContext) error {
+		calls.Add(1)
+		return nil
+	}))
+	engine.UseEventMiddleware(func(next gre.EventHandlerFunc) gre.EventHandlerFunc {
+		return func(event gre.Event, ctx gre.EventContext) error {
+			calls.Add(1)
+			return next(event, ctx)
+		}
+	})
+	engine.RegisterEvents(
+		gre.Event{Name: "approve", Params: map[string]interface{}{"channel": "email", "level": 1}, Action: count},
+		gre.Event{Name: "audit", Mode: gre.EventModeAsync, Action: count},
+	)
+	engine.AddRules(
+		&gre.Rule{
+			Name:       "adult",
+			Priority:   20,
+			Conditions: gre.All(gre.GreaterThanInclusive("age", 18)),
+			OnSuccess: []gre.RuleEvent{
+				{Name: "approve", Params: map[string]interface{}{"level": 2, "age": map[string]interface{}{"fact": "age"}}},
+				setFact("isAdult", true),
+			},
+		},
+		&gre.Rule{
+			Name:       "minor",
+			Priority:   10,
+			Conditions: gre.All(gre.LessThan("age", 18)),
+			OnFailure:  []gre.RuleEvent{{Name: "audit"}},
+		},
+	)
+	return engine, calls
+}
+
+func TestSimulate(t *testing.T) {
+	variants := map[string][]gre.EngineOption{
+		"sequential": nil,
+		"parallel":   {gre.WithParallelExecution(4)},
+	}
+
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			metrics := NewMockMetricsCollector()
+			engine, calls := newSimulationEngine(append(opts, gre.WithMetrics(metrics))...)
+
+			almanac := gre.NewAlmanac()
+			almanac.AddFact("age", 30)
+
+			run, err := engine.Simulate(almanac)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if n := calls.Load(); n != 0 {
+				t.Errorf("Expected no action, handler or middleware call, got %d", n)
+			}
+			if metrics.EngineRunCount != 0 || metrics.EventExecCount != 0 || len(metrics.RuleEvalCounts) != 0 {
+				t.Errorf("Expected the metrics to be untouched, got %+v", metrics)
+			}
+			if !run.Simulated() {
+				t.Error("Expected the run to be a simulation")
+			}
+
+			expected := []gre.SimulatedEvent{
+				{EventName: "approve", RuleName: "adult", Result: true, Params: map[string]interface{}{"channel": "email", "level": 2, "age": 30}},
+				{EventName: gre.SetFactEventName, RuleName: "adult", Result: true, Params: map[string]interface{}{"fact": "isAdult", "value": true}},
+				{EventName: "audit", RuleName: "minor", Result: false, Async: true, Params: map[string]interface{}{}},
+			}
+			if got := run.SimulatedEvents(); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, got)
+			}
+			if !reflect.DeepEqual(run.ReduceResults(), map[string]bool{"adult": true, "minor": false}) {
+				t.Errorf("Unexpected results %v", run.ReduceResults())
+			}
+		})
+	}
+}
+
+func TestSimulate_DoesNotAffectRealRuns(t *testing.T) {
+	engine, calls := newSimulationEngine()
+
+	almanac := gre.NewAlmanac()
+	almanac.AddFact("age", 30)
+	if _, err := engine.Simulate(almanac); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	almanac = gre.NewAlmanac()
+	almanac.AddFact("age", 30)
+	run, err := engine.Evaluate(almanac)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if run.Simulated() || run.SimulatedEvents() != nil {
+		t.Error("Expected a real run not to record simulated events")
+	}
+	if err := engine.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// approve and audit: middleware, action and handler each
+	if n := calls.Load(); n != 6 {
+		t.Errorf("Expected the events to be dispatched, got %d calls", n)
+	}
+}
@@ -55,6 +55,75 @@ const (
 
 	// OperatorRegex checks if the fact value matches the regex pattern in the condition value.
 	OperatorRegex OperatorType = "regex"
+
+	// OperatorBetween checks if the fact value is within the condition range (inclusive by default).
+	OperatorBetween OperatorType = "between"
+
+	// OperatorNotBetween checks if the fact value is outside the condition range.
+	OperatorNotBetween OperatorType = "not_between"
+
+	// OperatorStartsWith checks if the fact value starts with the condition value (strings).
+	OperatorStartsWith OperatorType = "starts_with"
+
+	// OperatorEndsWith checks if the fact value ends with the condition value (strings).
+	OperatorEndsWith OperatorType = "ends_with"
+
+	// OperatorEqualIgnoreCase checks if the fact value equals the condition value, ignoring case (strings).
+	OperatorEqualIgnoreCase OperatorType = "equal_ignore_case"
+
+	// OperatorInIgnoreCase checks if the fact value is in the condition value (array of strings), ignoring case.
+	OperatorInIgnoreCase OperatorType = "in_ignore_case"
+
+	// OperatorLengthEqual checks if the length of the fact value equals the condition value.
+	OperatorLengthEqual OperatorType = "length_equal"
+
+	// OperatorLengthGreaterThan checks if the length of the fact value is greater than the condition value.
+	OperatorLengthGreaterThan OperatorType = "length_greater_than"
+
+	// OperatorLengthGreaterThanInclusive checks if the length of the fact value is greater than or equal to the condition value.
+	OperatorLengthGreaterThanInclusive OperatorType = "length_greater_than_inclusive"
+
+	// OperatorLengthLessThan checks if the length of the fact value is less than the condition value.
+	OperatorLengthLessThan OperatorType = "length_less_than"
+
+	// OperatorLengthLessThanInclusive checks if the length of the fact value is less than or equal to the condition value.
+	OperatorLengthLessThanInclusive OperatorType = "length_less_than_inclusive"
+
+	// OperatorBefore checks if the fact time is before the condition time.
+	OperatorBefore OperatorType = "before"
+
+	// OperatorAfter checks if the fact time is after the condition time.
+	OperatorAfter OperatorType = "after"
+
+	// OperatorBetweenDates checks if the fact time is within the condition [from, to] range.
+	OperatorBetweenDates OperatorType = "between_dates"
+
+	// OperatorWithinLast checks if the fact time is within the condition duration before now.
+	OperatorWithinLast OperatorType = "within_last"
+
+	// OperatorOlderThan checks if the fact time is more than the condition duration before now.
+	OperatorOlderThan OperatorType = "older_than"
+
+	// OperatorDayOfWeekIn checks if the fact time falls on one of the condition days of the week.
+	OperatorDayOfWeekIn OperatorType = "day_of_week_in"
+
+	// OperatorTimeOfDayBetween checks if the fact time of day is within the condition range.
+	OperatorTimeOfDayBetween OperatorType = "time_of_day_between"
+
+	// OperatorExists checks if the fact is defined and its path resolves, even to a nil value.
+	OperatorExists OperatorType = "exists"
+
+	// OperatorNotExists checks if the fact is undefined or its path does not resolve.
+	OperatorNotExists OperatorType = "not_exists"
+
+	// OperatorIsNull checks if the fact exists with a nil value.
+	OperatorIsNull OperatorType = "is_null"
+
+	// OperatorIsEmpty checks if the fact is missing, nil, or an empty string, array or map.
+	OperatorIsEmpty OperatorType = "is_empty"
+
+	// OperatorIsNotEmpty checks if the fact exists and is not nil, nor an empty string, array or map.
+	OperatorIsNotEmpty OperatorType = "is_not_empty"
 )
 
 // MetricsCollector defines an interface for monitoring the rules engine's performance and execution results.
@@ -68,6 +137,25 @@ type MetricsCollector interface {
 	ObserveEventExecution(eventName string, ruleName string, result bool, duration time.Duration)
 }
 
+// EventExecutionCollector can be implemented by a MetricsCollector to also receive
+// the number of attempts, the error and the dead-lettering of each event.
+type EventExecutionCollector interface {
+	ObserveEventAttempts(execution EventExecution)
+}
+
+// RuleStatus tells why a rule result does not come from an evaluation.
+// It is empty for evaluated rules.
+type RuleStatus string
+
+const (
+	// RuleStatusSkipped marks a rule skipped by smart skip (a required fact is missing).
+	RuleStatusSkipped RuleStatus = "skipped"
+	// RuleStatusNotEvaluated marks a rule that was not reached because the run halted.
+	RuleStatusNotEvaluated RuleStatus = "not_evaluated"
+	// RuleStatusErrored marks a rule whose evaluation failed under ErrorPolicySkipRule.
+	RuleStatusErrored RuleStatus = "errored"
+)
+
 // RuleResult represents the complete evaluation result of a single rule.
 type RuleResult struct {
 	Name       string              `json:"name"`
@@ -76,6 +164,9 @@ type RuleResult struct {
 	Conditions *ConditionSetResult `json:"conditions"`
 	OnSuccess  []RuleEvent         `json:"onSuccess,omitempty"`
 	OnFailure  []RuleEvent         `json:"onFailure,omitempty"`
+	Effect     RuleEffect          `json:"effect,omitempty"`
+	Status     RuleStatus          `json:"status,omitempty"` // Set when the rule was not evaluated
+	Error      string              `json:"error,omitempty"`  // Errors that occurred while evaluating the rule or handling its events
 }
 
 // ConditionSetResult represents the evaluation result of a ConditionSet (All, Any, or None).
@@ -89,17 +180,58 @@ type ConditionSetResult struct {
 type ConditionNodeResult struct {
 	Condition    *ConditionResult    `json:"condition,omitempty"`
 	ConditionSet *ConditionSetResult `json:"conditionSet,omitempty"`
+	Quantifier   *QuantifierResult   `json:"quantifier,omitempty"`
+}
+
+// result returns the result of the node, whatever its kind.
+func (r *ConditionNodeResult) result() bool {
+	switch {
+	case r.Condition != nil:
+		return r.Condition.Result
+	case r.ConditionSet != nil:
+		return r.ConditionSet.Result
+	case r.Quantifier != nil:
+		return r.Quantifier.Result
+	}
+	return false
 }
 
 // ConditionResult represents the detailed evaluation result of a single Condition.
 type ConditionResult struct {
-	Fact      FactID       `json:"fact"`
-	Operator  OperatorType `json:"operator"`
-	Value     interface{}  `json:"value"`          // The value to compare against
-	FactValue interface{}  `json:"factValue"`      // The actual value fetched from the Almanac
-	Path      string       `json:"path,omitempty"` // The JSONPath used, if any
-	Result    bool         `json:"result"`
-	Error     string       `json:"error,omitempty"`
+	Fact         FactID       `json:"fact"`
+	Rule         string       `json:"rule,omitempty"` // The referenced rule, for rule conditions
+	Operator     OperatorType `json:"operator"`
+	Value        interface{}  `json:"value"`                  // The value to compare against
+	CompareValue interface{}  `json:"compareValue,omitempty"` // The resolved value when Value is a FactReference
+	FactValue    interface{}  `json:"factValue"`              // The actual value fetched from the Almanac
+	Path         string       `json:"path,omitempty"`         // The JSONPath used, if any
+	FactStatus   FactStatus   `json:"factStatus,omitempty"`   // Whether the fact was found, for presence operators
+	Result       bool         `json:"result"`
+	Error        string       `json:"error,omitempty"`
+}
+
+// QuantifierResult represents the evaluation result of a QuantifierCondition,
+// with the result of each evaluated element.
+type QuantifierResult struct {
+	Fact         FactID          `json:"fact"`
+	Path         string          `json:"path,omitempty"`
+	Quantifier   Quantifier      `json:"quantifier"`
+	Operator     OperatorType    `json:"operator,omitempty"`     // The count comparison operator, for count
+	Value        interface{}     `json:"value,omitempty"`        // The value the count is compared to, for count
+	CompareValue interface{}     `json:"compareValue,omitempty"` // The resolved value when Value is a FactReference
+	Count        int             `json:"count"`                  // The number of matching elements among the evaluated ones
+	Elements     []ElementResult `json:"elements"`               // The evaluated elements, in order
+	Result       bool            `json:"result"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// ElementResult represents the evaluation result of the nested conditions of a
+// quantifier for one element of the collection.
+type ElementResult struct {
+	Index      int                 `json:"index"`
+	Value      interface{}         `json:"value"`
+	Result     bool                `json:"result"`
+	Conditions *ConditionSetResult `json:"conditions"`
 }
 
 const (
@@ -111,14 +243,19 @@ const (
 
 // EngineResponse represents the final formatted structure for your JSON response.
 type EngineResponse struct {
-	Decision string                 `json:"decision"` // DecisionAuthorize or DecisionDecline
-	Reason   interface{}            `json:"reason"`   // Detail of conditions (if AuditTrace is active)
-	Events   []EventResponse        `json:"events"`   // List of triggered events
-	Metadata map[string]interface{} `json:"metadata"` // Metadata from facts or other sources
+	Decision      string                 `json:"decision"`        // DecisionAuthorize or DecisionDecline
+	Policy        string                 `json:"policy"`          // Name of the hit policy that made the decision
+	DecidingRules []string               `json:"decidingRules"`   // Rules that determined the decision
+	Score         *float64               `json:"score,omitempty"` // Total weight of the matching rules, for score-based policies
+	Error         string                 `json:"error,omitempty"` // Why the hit policy could not decide
+	Reason        interface{}            `json:"reason"`          // Detail of conditions (if AuditTrace is active)
+	Events        []EventResponse        `json:"events"`          // List of triggered events
+	Metadata      map[string]interface{} `json:"metadata"`        // Metadata from facts or other sources
 }
 
 // EventResponse represents a simplified event.
 type EventResponse struct {
 	Type   string                 `json:"type"`
-	Params map[string]interface{} `json:"params,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"` // Params with fact references and templates resolved
+	Error  string                 `json:"error,omitempty"`  // Why the params could not be resolved
 }
@@ -0,0 +1,413 @@
+package gorulesengine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity indicates how serious a validation diagnostic is.
+type Severity string
+
+const (
+	// SeverityError marks a problem that makes the rule fail or misbehave at run time.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a suspicious construct that is still evaluated.
+	SeverityWarning Severity = "warning"
+)
+
+// DiagnosticCode identifies the kind of problem reported by a Diagnostic.
+type DiagnosticCode string
+
+const (
+	// DiagnosticEmptyNode reports a node with neither a condition nor a nested set.
+	DiagnosticEmptyNode DiagnosticCode = "EMPTY_NODE"
+	// DiagnosticEmptyConditionSet reports a condition set without all/any/none nodes (always true).
+	DiagnosticEmptyConditionSet DiagnosticCode = "EMPTY_CONDITION_SET"
+	// DiagnosticAmbiguousConditionSet reports a condition set mixing all/any/none (only the first is evaluated).
+	DiagnosticAmbiguousConditionSet DiagnosticCode = "AMBIGUOUS_CONDITION_SET"
+	// DiagnosticMissingFact reports a condition without a fact.
+	DiagnosticMissingFact DiagnosticCode = "MISSING_FACT"
+	// DiagnosticUnknownOperator reports a condition using an operator that is not registered.
+	DiagnosticUnknownOperator DiagnosticCode = "UNKNOWN_OPERATOR"
+	// DiagnosticInvalidValue reports a condition value rejected by its operator (bad regex, non-array for in, ...).
+	DiagnosticInvalidValue DiagnosticCode = "INVALID_VALUE"
+	// DiagnosticMissingRuleName reports a rule without a name.
+	DiagnosticMissingRuleName DiagnosticCode = "MISSING_RULE_NAME"
+	// DiagnosticDuplicateRuleName reports several rules sharing the same name.
+	DiagnosticDuplicateRuleName DiagnosticCode = "DUPLICATE_RULE_NAME"
+	// DiagnosticMissingEventName reports a rule event without a name.
+	DiagnosticMissingEventName DiagnosticCode = "MISSING_EVENT_NAME"
+	// DiagnosticUnregisteredEvent reports a rule event that is not registered in the engine.
+	DiagnosticUnregisteredEvent DiagnosticCode = "UNREGISTERED_EVENT"
+	// DiagnosticCompileFailed reports a rule that could not be compiled.
+	DiagnosticCompileFailed DiagnosticCode = "COMPILE_FAILED"
+	// DiagnosticAmbiguousCondition reports a condition defining both a fact and a rule (the fact is ignored).
+	DiagnosticAmbiguousCondition DiagnosticCode = "AMBIGUOUS_CONDITION"
+	// DiagnosticUnknownRule reports a rule condition referencing a rule that is not in the engine.
+	DiagnosticUnknownRule DiagnosticCode = "UNKNOWN_RULE"
+	// DiagnosticRuleCycle reports rule conditions that depend on each other in a cycle.
+	DiagnosticRuleCycle DiagnosticCode = "RULE_CYCLE"
+	// DiagnosticInvalidEventParams reports a built-in event whose params are invalid.
+	DiagnosticInvalidEventParams DiagnosticCode = "INVALID_EVENT_PARAMS"
+	// DiagnosticInvalidEffect reports a rule effect other than permit or deny.
+	DiagnosticInvalidEffect DiagnosticCode = "INVALID_EFFECT"
+	// DiagnosticInvalidStopProcessing reports a stopProcessing mode other than success, failure or always.
+	DiagnosticInvalidStopProcessing DiagnosticCode = "INVALID_STOP_PROCESSING"
+	// DiagnosticInvalidQuantifier reports a quantifier other than some, every, none_of and count.
+	DiagnosticInvalidQuantifier DiagnosticCode = "INVALID_QUANTIFIER"
+)
+
+// Diagnostic describes a single problem found while validating a rule.
+type Diagnostic struct {
+	Rule     string         `json:"rule"`     // Name of the offending rule
+	Path     string         `json:"path"`     // JSON path to the offending node, e.g. "$.conditions.all[0]"
+	Severity Severity       `json:"severity"` // SeverityError or SeverityWarning
+	Code     DiagnosticCode `json:"code"`     // Machine-readable problem code
+	Message  string         `json:"message"`  // Human-readable description
+}
+
+// String formats the diagnostic for logs.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: rule '%s' at %s: [%s] %s", d.Severity, d.Rule, d.Path, d.Code, d.Message)
+}
+
+// Diagnostics is a list of validation diagnostics.
+type Diagnostics []Diagnostic
+
+// HasErrors reports whether at least one diagnostic has SeverityError.
+func (d Diagnostics) HasErrors() bool {
+	for _, diag := range d {
+		if diag.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the diagnostics with SeverityError.
+func (d Diagnostics) Errors() Diagnostics {
+	var errs Diagnostics
+	for _, diag := range d {
+		if diag.Severity == SeverityError {
+			errs = append(errs, diag)
+		}
+	}
+	return errs
+}
+
+// Err returns a ValidationError holding the error diagnostics, or nil if there are none.
+func (d Diagnostics) Err() error {
+	errs := d.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Diagnostics: errs}
+}
+
+// OperatorValidator can be implemented by an Operator to check a condition value
+// ahead of evaluation (e.g. that a regex pattern compiles).
+// Validation is skipped for operators that do not implement it.
+type OperatorValidator interface {
+	// ValidateValue returns an error if compareValue can never be evaluated by the operator.
+	ValidateValue(compareValue interface{}) error
+}
+
+// ValidateRule checks the structure of a rule and the values of its conditions
+// against their operators. It does not check event registration, which depends
+// on the engine; use Engine.ValidateRules for that.
+//
+// Example:
+//
+//	diags := gre.ValidateRule(rule)
+//	if diags.HasErrors() {
+//	    for _, d := range diags {
+//	        log.Println(d)
+//	    }
+//	}
+func ValidateRule(rule *Rule) Diagnostics {
+	v := &ruleValidator{rule: rule}
+
+	if rule.Name == "" {
+		v.add("$.name", SeverityWarning, DiagnosticMissingRuleName, "rule has no name; its result cannot be told apart from other unnamed rules")
+	}
+
+	switch rule.Effect {
+	case "", RuleEffectPermit, RuleEffectDeny:
+	default:
+		v.add("$.effect", SeverityError, DiagnosticInvalidEffect, fmt.Sprintf("unknown effect '%s' (expected '%s' or '%s')", rule.Effect, RuleEffectPermit, RuleEffectDeny))
+	}
+
+	switch rule.StopProcessing {
+	case "", StopOnSuccess, StopOnFailure, StopAlways:
+	default:
+		v.add("$.stopProcessing", SeverityError, DiagnosticInvalidStopProcessing, fmt.Sprintf("unknown stopProcessing mode '%s' (expected '%s', '%s' or '%s')", rule.StopProcessing, StopOnSuccess, StopOnFailure, StopAlways))
+	}
+
+	v.validateConditionSet(&rule.Conditions, "$.conditions")
+
+	v.validateEvents(rule.OnSuccess, "$.onSuccess")
+	v.validateEvents(rule.OnFailure, "$.onFailure")
+
+	return v.diags
+}
+
+// validateRuleForEngine validates a rule and compiles it. A compile failure is
+// only reported when validation did not already explain it.
+func validateRuleForEngine(rule *Rule) Diagnostics {
+	diags := ValidateRule(rule)
+	if err := rule.Compile(); err != nil && !diags.HasErrors() {
+		diags = append(diags, Diagnostic{
+			Rule:     rule.Name,
+			Path:     "$",
+			Severity: SeverityError,
+			Code:     DiagnosticCompileFailed,
+			Message:  err.Error(),
+		})
+	}
+	return diags
+}
+
+// validateEvents checks the names of rule events and the params of built-in events.
+func (v *ruleValidator) validateEvents(events []RuleEvent, path string) {
+	for i, event := range events {
+		eventPath := fmt.Sprintf("%s[%d]", path, i)
+		switch event.Name {
+		case "":
+			v.add(eventPath, SeverityError, DiagnosticMissingEventName, "event has no name")
+		case SetFactEventName:
+			if id, _ := event.Params["fact"].(string); id == "" {
+				v.add(eventPath+".params.fact", SeverityError, DiagnosticInvalidEventParams,
+					fmt.Sprintf("event '%s' requires a non-empty string 'fact' parameter", SetFactEventName))
+			}
+		}
+		v.validateParamTemplates(event.Params, eventPath+".params")
+	}
+}
+
+// validateParamTemplates warns about the templated event params that cannot be
+// parsed. They are only rendered with event param resolution, so they may as well
+// be meant for a downstream template.
+func (v *ruleValidator) validateParamTemplates(value interface{}, path string) {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v.validateParamTemplates(val[key], path+"."+key)
+		}
+	case []interface{}:
+		for i, item := range val {
+			v.validateParamTemplates(item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case string:
+		if !strings.Contains(val, "{{") {
+			return
+		}
+		if _, err := parseParamTemplate(val); err != nil {
+			v.add(path, SeverityWarning, DiagnosticInvalidEventParams, fmt.Sprintf("invalid param template: %v", err))
+		}
+	}
+}
+
+// ruleValidator accumulates diagnostics while walking a rule.
+type ruleValidator struct {
+	rule  *Rule
+	diags Diagnostics
+}
+
+func (v *ruleValidator) add(path string, severity Severity, code DiagnosticCode, msg string) {
+	v.diags = append(v.diags, Diagnostic{
+		Rule:     v.rule.Name,
+		Path:     path,
+		Severity: severity,
+		Code:     code,
+		Message:  msg,
+	})
+}
+
+func (v *ruleValidator) validateConditionSet(cs *ConditionSet, path string) {
+	var types []string
+	if len(cs.All) > 0 {
+		types = append(types, string(AllType))
+	}
+	if len(cs.Any) > 0 {
+		types = append(types, string(AnyType))
+	}
+	if len(cs.None) > 0 {
+		types = append(types, string(NoneType))
+	}
+
+	switch {
+	case len(types) == 0:
+		v.add(path, SeverityWarning, DiagnosticEmptyConditionSet, "condition set has no all/any/none nodes and always evaluates to true")
+	case len(types) > 1:
+		v.add(path, SeverityWarning, DiagnosticAmbiguousConditionSet,
+			fmt.Sprintf("condition set defines %s; only '%s' is evaluated", strings.Join(types, ", "), types[0]))
+	}
+
+	v.validateNodes(cs.All, path+".all")
+	v.validateNodes(cs.Any, path+".any")
+	v.validateNodes(cs.None, path+".none")
+}
+
+func (v *ruleValidator) validateNodes(nodes []ConditionNode, path string) {
+	for i := range nodes {
+		nodePath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case nodes[i].Condition != nil:
+			v.validateCondition(nodes[i].Condition, nodePath)
+		case nodes[i].SubSet != nil:
+			v.validateConditionSet(nodes[i].SubSet, nodePath)
+		case nodes[i].Quantifier != nil:
+			v.validateQuantifier(nodes[i].Quantifier, nodePath)
+		default:
+			v.add(nodePath, SeverityError, DiagnosticEmptyNode, "node defines neither a condition nor a nested condition set")
+		}
+	}
+}
+
+func (v *ruleValidator) validateCondition(c *Condition, path string) {
+	switch {
+	case c.Fact == "" && c.Rule == "":
+		v.add(path+".fact", SeverityError, DiagnosticMissingFact, "condition has no fact")
+	case c.Fact != "" && c.Rule != "":
+		v.add(path, SeverityWarning, DiagnosticAmbiguousCondition,
+			fmt.Sprintf("condition defines both fact '%s' and rule '%s'; the fact is ignored", c.Fact, c.Rule))
+	}
+
+	v.validateOperatorValue(c.Operator, c.Value, path)
+}
+
+// validateOperatorValue checks that operator is registered and accepts value.
+func (v *ruleValidator) validateOperatorValue(op OperatorType, value interface{}, path string) {
+	operator, err := GetOperator(op)
+	if err != nil {
+		v.add(path+".operator", SeverityError, DiagnosticUnknownOperator, fmt.Sprintf("operator '%s' is not registered", op))
+		return
+	}
+
+	// Referenced values are only known at evaluation time
+	if ref, ok := asFactReference(value); ok {
+		if ref.Fact == "" {
+			v.add(path+".value.fact", SeverityError, DiagnosticMissingFact, "fact reference has no fact")
+		}
+		return
+	}
+
+	if validator, ok := operator.(OperatorValidator); ok {
+		if err := validator.ValidateValue(value); err != nil {
+			msg := err.Error()
+			var opErr *OperatorError
+			if errors.As(err, &opErr) && opErr.Err != nil {
+				msg = opErr.Err.Error()
+			}
+			v.add(path+".value", SeverityError, DiagnosticInvalidValue, msg)
+		}
+	}
+}
+
+func (v *ruleValidator) validateQuantifier(q *QuantifierCondition, path string) {
+	if q.Fact == "" {
+		v.add(path+".fact", SeverityError, DiagnosticMissingFact, "quantifier has no fact")
+	}
+
+	switch q.Quantifier {
+	case QuantifierSome, QuantifierEvery, QuantifierNoneOf:
+		if q.Operator != "" {
+			v.add(path+".operator", SeverityWarning, DiagnosticInvalidQuantifier,
+				fmt.Sprintf("operator '%s' is ignored by the '%s' quantifier; only count compares", q.Operator, q.Quantifier))
+		}
+	case QuantifierCount:
+		v.validateOperatorValue(q.Operator, q.Value, path)
+	default:
+		v.add(path+".quantifier", SeverityError, DiagnosticInvalidQuantifier,
+			fmt.Sprintf("quantifier '%s' must be one of some, every, none_of and count", q.Quantifier))
+	}
+
+	v.validateConditionSet(&q.Where, path+".where")
+}
+
+// ValidateRules validates every rule registered in the engine. In addition to
+// ValidateRule, it reports rules that fail to compile, duplicate rule names,
+// rule conditions referencing unknown rules or forming a cycle, and events that
+// are not registered in the engine (an error when a global event handler is set,
+// since the run would fail; a warning otherwise, since the event would be
+// silently ignored).
+func (e *Engine) ValidateRules() Diagnostics {
+	e.mu.RLock()
+	rules := make([]*Rule, len(e.rules))
+	copy(rules, e.rules)
+	events := make(map[string]bool, len(e.events))
+	for name := range e.events {
+		events[name] = true
+	}
+	hasHandler := e.eventHandler != nil
+	e.mu.RUnlock()
+
+	var diags Diagnostics
+	seen := make(map[string]bool, len(rules))
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		names[rule.Name] = true
+	}
+
+	for _, rule := range rules {
+		diags = append(diags, validateRuleForEngine(rule)...)
+
+		if rule.Name != "" {
+			if seen[rule.Name] {
+				diags = append(diags, Diagnostic{
+					Rule:     rule.Name,
+					Path:     "$.name",
+					Severity: SeverityWarning,
+					Code:     DiagnosticDuplicateRuleName,
+					Message:  fmt.Sprintf("rule name '%s' is used by several rules; only the last result is kept", rule.Name),
+				})
+			}
+			seen[rule.Name] = true
+		}
+
+		severity := SeverityWarning
+		if hasHandler {
+			severity = SeverityError
+		}
+		checkEvents := func(list []RuleEvent, field string) {
+			for i, event := range list {
+				if event.Name != "" && event.Name != SetFactEventName && !events[event.Name] {
+					diags = append(diags, Diagnostic{
+						Rule:     rule.Name,
+						Path:     fmt.Sprintf("$.%s[%d]", field, i),
+						Severity: severity,
+						Code:     DiagnosticUnregisteredEvent,
+						Message:  fmt.Sprintf("event '%s' is not registered in the engine", event.Name),
+					})
+				}
+			}
+		}
+		checkEvents(rule.OnSuccess, "onSuccess")
+		checkEvents(rule.OnFailure, "onFailure")
+
+		for _, name := range rule.GetRequiredRules() {
+			if !names[name] {
+				diags = append(diags, Diagnostic{
+					Rule:     rule.Name,
+					Path:     "$.conditions",
+					Severity: SeverityError,
+					Code:     DiagnosticUnknownRule,
+					Message:  fmt.Sprintf("rule condition references unknown rule '%s'", name),
+				})
+			}
+		}
+	}
+
+	if cycle := newRuleGraph(rules).findCycle(); cycle != nil {
+		diags = append(diags, newRuleCycleDiagnostic(cycle))
+	}
+
+	return diags
+}
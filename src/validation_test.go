@@ -0,0 +1,260 @@
+package gorulesengine_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	gre "github.com/deadelus/go-rules-engine/v2/src"
+)
+
+// findDiagnostic returns the first diagnostic with the given code and path.
+func findDiagnostic(diags gre.Diagnostics, code gre.DiagnosticCode, path string) *gre.Diagnostic {
+	for i := range diags {
+		if diags[i].Code == code && diags[i].Path == path {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+func TestValidateRule(t *testing.T) {
+	t.Run("valid rule has no diagnostics", func(t *testing.T) {
+		rule := &gre.Rule{
+			Name: "valid",
+			Conditions: gre.All(
+				gre.GreaterThan("age", 18),
+				gre.In("country", []string{"FR", "BE"}),
+				gre.Regex("email", `^[a-z]+@example\.com$`),
+			),
+			OnSuccess: []gre.RuleEvent{{Name: "ok"}},
+		}
+
+		if diags := gre.ValidateRule(rule); len(diags) != 0 {
+			t.Errorf("Expected no diagnostics, got %v", diags)
+		}
+	})
+
+	t.Run("reports problems with JSON paths", func(t *testing.T) {
+		rulesJSON := `{
+			"name": "broken",
+			"conditions": {
+				"all": [
+					{"fact": "age", "operator": "bigger_than", "value": 18},
+					{"any": [
+						{"fact": "email", "operator": "regex", "value": "[a-z"},
+						{"fact": "country", "operator": "in", "value": "FR"},
+						{"fact": "score", "operator": "greater_than", "value": "high"}
+					]},
+					{}
+				]
+			},
+			"onSuccess": [{"name": "", "params": {"a": 1}}]
+		}`
+
+		var rule gre.Rule
+		if err := json.Unmarshal([]byte(rulesJSON), &rule); err != nil {
+			t.Fatalf("Failed to unmarshal rule: %v", err)
+		}
+
+		diags := gre.ValidateRule(&rule)
+
+		expected := []struct {
+			code     gre.DiagnosticCode
+			path     string
+			severity gre.Severity
+		}{
+			{gre.DiagnosticUnknownOperator, "$.conditions.all[0].operator", gre.SeverityError},
+			{gre.DiagnosticInvalidValue, "$.conditions.all[1].any[0].value", gre.SeverityError},
+			{gre.DiagnosticInvalidValue, "$.conditions.all[1].any[1].value", gre.SeverityError},
+			{gre.DiagnosticInvalidValue, "$.conditions.all[1].any[2].value", gre.SeverityError},
+			{gre.DiagnosticEmptyConditionSet, "$.conditions.all[2]", gre.SeverityWarning},
+			{gre.DiagnosticMissingEventName, "$.onSuccess[0]", gre.SeverityError},
+		}
+		for _, exp := range expected {
+			diag := findDiagnostic(diags, exp.code, exp.path)
+			if diag == nil {
+				t.Errorf("Expected %s at %s, got %v", exp.code, exp.path, diags)
+				continue
+			}
+			if diag.Severity != exp.severity {
+				t.Errorf("Expected severity %s for %s, got %s", exp.severity, exp.code, diag.Severity)
+			}
+			if diag.Rule != "broken" || diag.Message == "" {
+				t.Errorf("Expected rule name and message, got %+v", diag)
+			}
+		}
+		if len(diags) != len(expected) {
+			t.Errorf("Expected %d diagnostics, got %d: %v", len(expected), len(diags), diags)
+		}
+		if !diags.HasErrors() {
+			t.Error("Expected HasErrors to be true")
+		}
+	})
+
+	t.Run("reports structural warnings", func(t *testing.T) {
+		rule := &gre.Rule{
+			Conditions: gre.ConditionSet{
+				All: []gre.ConditionNode{{Condition: gre.Equal("a", 1)}},
+				Any: []gre.ConditionNode{{Condition: gre.Equal("b", 1)}, {}, {Condition: gre.Equal("", 1)}},
+			},
+		}
+
+		diags := gre.ValidateRule(rule)
+
+		if findDiagnostic(diags, gre.DiagnosticMissingRuleName, "$.name") == nil {
+			t.Errorf("Expected missing rule name warning, got %v", diags)
+		}
+		if findDiagnostic(diags, gre.DiagnosticAmbiguousConditionSet, "$.conditions") == nil {
+			t.Errorf("Expected ambiguous condition set warning, got %v", diags)
+		}
+		if findDiagnostic(diags, gre.DiagnosticEmptyNode, "$.conditions.any[1]") == nil {
+			t.Errorf("Expected empty node error, got %v", diags)
+		}
+		if findDiagnostic(diags, gre.DiagnosticMissingFact, "$.conditions.any[2].fact") == nil {
+			t.Errorf("Expected missing fact error, got %v", diags)
+		}
+		if len(diags.Errors()) != 2 {
+			t.Errorf("Expected 2 error diagnostics, got %v", diags.Errors())
+		}
+	})
+
+	t.Run("Err wraps error diagnostics only", func(t *testing.T) {
+		warnings := gre.Diagnostics{{Severity: gre.SeverityWarning}}
+		if warnings.Err() != nil {
+			t.Error("Expected no error for warnings only")
+		}
+
+		diags := gre.Diagnostics{{Rule: "r", Severity: gre.SeverityError, Code: gre.DiagnosticMissingFact}}
+		var valErr *gre.ValidationError
+		if !errors.As(diags.Err(), &valErr) || len(valErr.Diagnostics) != 1 {
+			t.Fatalf("Expected ValidationError with 1 diagnostic, got %v", diags.Err())
+		}
+		if valErr.Error() == "" {
+			t.Error("Expected error message")
+		}
+	})
+}
+
+func TestEngine_ValidateRules(t *testing.T) {
+	newRules := func() (*gre.Rule, *gre.Rule) {
+		return &gre.Rule{
+			Name:       "rule",
+			Conditions: gre.All(gre.Equal("a", 1)),
+			OnSuccess:  []gre.RuleEvent{{Name: "registered"}},
+			OnFailure:  []gre.RuleEvent{{Name: "missing"}},
+		}, &gre.Rule{
+			Name:       "rule",
+			Conditions: gre.All(gre.Equal("b", 1)),
+		}
+	}
+
+	t.Run("reports unregistered events and duplicate names", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.RegisterEvent(gre.Event{Name: "registered"})
+		engine.AddRules(newRules())
+
+		diags := engine.ValidateRules()
+
+		unregistered := findDiagnostic(diags, gre.DiagnosticUnregisteredEvent, "$.onFailure[0]")
+		if unregistered == nil || unregistered.Severity != gre.SeverityWarning {
+			t.Errorf("Expected unregistered event warning, got %v", diags)
+		}
+		if findDiagnostic(diags, gre.DiagnosticUnregisteredEvent, "$.onSuccess[0]") != nil {
+			t.Error("Expected registered event not to be reported")
+		}
+		if findDiagnostic(diags, gre.DiagnosticDuplicateRuleName, "$.name") == nil {
+			t.Errorf("Expected duplicate rule name warning, got %v", diags)
+		}
+	})
+
+	t.Run("unregistered events are errors with a global handler", func(t *testing.T) {
+		engine := gre.NewEngine()
+		engine.SetEventHandler(&MockEventHandler{})
+		rule, _ := newRules()
+		engine.AddRule(rule)
+
+		diags := engine.ValidateRules()
+
+		diag := findDiagnostic(diags, gre.DiagnosticUnregisteredEvent, "$.onSuccess[0]")
+		if diag == nil || diag.Severity != gre.SeverityError {
+			t.Errorf("Expected unregistered event error, got %v", diags)
+		}
+	})
+}
+
+func TestEngine_TryAddRules(t *testing.T) {
+	invalid := &gre.Rule{
+		Name:       "invalid",
+		Conditions: gre.All(gre.Regex("email", "[a-z")),
+	}
+	valid := &gre.Rule{
+		Name:       "valid",
+		Conditions: gre.All(gre.Equal("a", 1)),
+	}
+
+	t.Run("returns the error but keeps the rule without validation option", func(t *testing.T) {
+		engine := gre.NewEngine()
+
+		err := engine.TryAddRule(invalid)
+
+		var valErr *gre.ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("Expected ValidationError, got %v", err)
+		}
+		if valErr.Diagnostics[0].Code != gre.DiagnosticInvalidValue {
+			t.Errorf("Expected invalid value diagnostic, got %v", valErr.Diagnostics)
+		}
+		if len(engine.GetRules()) != 1 {
+			t.Errorf("Expected rule to be added, got %d rules", len(engine.GetRules()))
+		}
+	})
+
+	t.Run("rejects invalid rules with validation option", func(t *testing.T) {
+		engine := gre.NewEngine(gre.WithRuleValidation())
+
+		if err := engine.TryAddRules(valid, invalid); err == nil {
+			t.Fatal("Expected validation error")
+		}
+		if len(engine.GetRules()) != 0 {
+			t.Errorf("Expected no rule to be added, got %d", len(engine.GetRules()))
+		}
+
+		engine.AddRule(invalid)
+		engine.AddRules(valid, invalid)
+		if rules := engine.GetRules(); len(rules) != 1 || rules[0].Name != "valid" {
+			t.Errorf("Expected only the valid rule, got %v", rules)
+		}
+	})
+
+	t.Run("keeps current rules when a replacement set is invalid", func(t *testing.T) {
+		engine := gre.NewEngine(gre.WithRuleValidation())
+		engine.AddRule(valid)
+
+		if err := engine.TrySetRules([]*gre.Rule{invalid}); err == nil {
+			t.Fatal("Expected validation error")
+		}
+		engine.SetRules([]*gre.Rule{invalid})
+
+		if rules := engine.GetRules(); len(rules) != 1 || rules[0].Name != "valid" {
+			t.Errorf("Expected previous rules to be kept, got %v", rules)
+		}
+
+		if err := engine.TrySetRules([]*gre.Rule{valid, valid}); err != nil {
+			t.Errorf("Expected no error for valid rules, got %v", err)
+		}
+	})
+
+	t.Run("options can be toggled", func(t *testing.T) {
+		engine := gre.NewEngine(gre.WithRuleValidation(), gre.WithoutRuleValidation())
+		engine.AddRule(invalid)
+		if len(engine.GetRules()) != 1 {
+			t.Error("Expected invalid rule to be added when validation is disabled")
+		}
+
+		gre.WithRuleValidation()(nil)
+		gre.WithoutRuleValidation()(nil)
+		gre.WithRuleValidation()(&gre.Engine{})
+		gre.WithoutRuleValidation()(&gre.Engine{})
+	})
+}